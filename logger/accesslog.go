@@ -0,0 +1,244 @@
+package logger
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// FlagAccess marks an access log entry
+const FlagAccess LogFlag = "ACCESS"
+
+// AccessLogFormat is an access log template, dipakai sebagai Formatter untuk sink access log.
+// Placeholder: {remote_addr}, {user}, {time}, {method}, {uri}, {protocol}, {status}, {bytes},
+// {referer}, {user_agent}, {duration} dan {transaction_id}. Nilai kosong ditulis "-".
+type AccessLogFormat string
+
+const (
+	// AccessLogCommon is the Apache Common Log Format
+	AccessLogCommon AccessLogFormat = `{remote_addr} - {user} [{time}] "{method} {uri} {protocol}" {status} {bytes}`
+	// AccessLogCombined is the Apache Combined Log Format
+	AccessLogCombined AccessLogFormat = `{remote_addr} - {user} [{time}] "{method} {uri} {protocol}" {status} {bytes} "{referer}" "{user_agent}"`
+)
+
+// clfTimeLayout is the time layout of the Common Log Format
+const clfTimeLayout = "02/Jan/2006:15:04:05 -0700"
+
+// AccessLogInfo holds the request and response values of one access log line
+type AccessLogInfo struct {
+	RemoteAddr string
+	User       string
+	Method     string
+	URI        string
+	Protocol   string
+	Status     int
+	Bytes      int64
+	Referer    string
+	UserAgent  string
+	Duration   time.Duration // 0 = dihitung dari start time di context
+	StartTime  time.Time     // Waktu request diterima untuk {time} (zero = start time di context)
+}
+
+// NewAccessLogInfo fills an AccessLogInfo from r and the response status and size
+func NewAccessLogInfo(r *http.Request, status int, bytes int64) AccessLogInfo {
+	remoteAddr := r.RemoteAddr
+	if host, _, err := net.SplitHostPort(remoteAddr); err == nil {
+		remoteAddr = host
+	}
+	user := ""
+	if r.URL.User != nil {
+		user = r.URL.User.Username()
+	} else if username, _, ok := r.BasicAuth(); ok {
+		user = username
+	}
+	uri := r.RequestURI
+	if uri == "" {
+		uri = r.URL.RequestURI()
+	}
+	return AccessLogInfo{
+		RemoteAddr: remoteAddr,
+		User:       user,
+		Method:     r.Method,
+		URI:        uri,
+		Protocol:   r.Proto,
+		Status:     status,
+		Bytes:      bytes,
+		Referer:    r.Referer(),
+		UserAgent:  r.UserAgent(),
+	}
+}
+
+// fields returns the access values as structured fields
+func (a AccessLogInfo) fields() []Field {
+	return []Field{
+		String("remote_addr", a.RemoteAddr),
+		String("user", a.User),
+		String("uri", a.URI),
+		String("protocol", a.Protocol),
+		Int("status", a.Status),
+		Int64("bytes", a.Bytes),
+		String("referer", a.Referer),
+		String("user_agent", a.UserAgent),
+		Duration("duration", a.Duration),
+		Any("start_time", a.StartTime),
+	}
+}
+
+// LogAccess writes an access log entry (flag ACCESS) with the correlation fields from ctx.
+// Dengan sink yang memakai AccessLogFormat, entry ditulis sebagai satu baris access log;
+// dengan format lain (text, json, logfmt) nilai access ditulis sebagai structured fields.
+func (l *Logger) LogAccess(ctx context.Context, info AccessLogInfo) {
+	level := LevelForStatus(info.Status)
+	if !l.enabled(levelFromString(level)) {
+		return
+	}
+	if startTime, ok := getStartTimeFromContext(ctx); ok {
+		if info.Duration == 0 {
+			info.Duration = l.now().Sub(startTime)
+		}
+		if info.StartTime.IsZero() {
+			info.StartTime = startTime
+		}
+	}
+	if info.StartTime.IsZero() {
+		// Tanpa start time, request dianggap diterima sebelum durasinya
+		info.StartTime = l.now().Add(-info.Duration)
+	}
+
+	message := fmt.Sprintf("%s %s %s", info.Method, info.URI, info.Protocol)
+	entry := l.newMandatoryEntry(ctx, level, FlagAccess, message, "")
+	entry.MethodType = info.Method
+	entry.Fields = append(entry.Fields[:len(entry.Fields):len(entry.Fields)], info.fields()...)
+	entry.File, entry.Line, entry.Function = getExternalCallerInfo()
+
+	l.enqueue(&logMessage{level: level, entry: entry})
+}
+
+// Format renders entry with the template. Entry selain access log ditulis dengan format text.
+func (f AccessLogFormat) Format(entry *LogEntry) string {
+	if entry.Flag != FlagAccess {
+		return formatText(entry)
+	}
+
+	// Nilai access ada di akhir Fields, sehingga field dari context dengan key sama tidak dipakai.
+	// Seperti Apache %t, {time} adalah waktu request diterima, bukan waktu entry ditulis.
+	values := map[string]string{
+		"time":           entry.Time.Format(clfTimeLayout),
+		"method":         entry.MethodType,
+		"transaction_id": entry.TransactionID,
+	}
+	for _, field := range entry.Fields {
+		switch field.Key {
+		case "remote_addr", "user", "uri", "protocol", "status", "referer", "user_agent":
+			values[field.Key] = field.ValueString()
+		case "bytes":
+			// Seperti Apache %b, response tanpa body ditulis "-"
+			values[field.Key] = field.ValueString()
+			if values[field.Key] == "0" {
+				values[field.Key] = ""
+			}
+		case "start_time":
+			if t, ok := field.Value.(time.Time); ok && !t.IsZero() {
+				values["time"] = t.Format(clfTimeLayout)
+			}
+		case "duration":
+			if d, ok := field.Value.(time.Duration); ok {
+				values[field.Key] = strconv.FormatFloat(float64(d)/float64(time.Millisecond), 'f', 3, 64) + "ms"
+			}
+		}
+	}
+
+	var b strings.Builder
+	template := string(f)
+	for {
+		start := strings.IndexByte(template, '{')
+		if start < 0 {
+			break
+		}
+		end := strings.IndexByte(template[start:], '}')
+		if end < 0 {
+			break
+		}
+		name := template[start+1 : start+end]
+		value, known := values[name]
+		b.WriteString(template[:start])
+		switch {
+		case !known && !isAccessPlaceholder(name):
+			// Bukan placeholder, tulis apa adanya
+			b.WriteString(template[start : start+end+1])
+		case value == "":
+			b.WriteByte('-')
+		default:
+			b.WriteString(strings.ReplaceAll(value, `"`, `\"`))
+		}
+		template = template[start+end+1:]
+	}
+	b.WriteString(template)
+	return b.String()
+}
+
+// isAccessPlaceholder reports whether name is a supported placeholder
+func isAccessPlaceholder(name string) bool {
+	switch name {
+	case "remote_addr", "user", "time", "method", "uri", "protocol", "status", "bytes",
+		"referer", "user_agent", "duration", "transaction_id":
+		return true
+	}
+	return false
+}
+
+// AccessLogConfig untuk logger khusus access log, terpisah dari application log
+type AccessLogConfig struct {
+	Format     AccessLogFormat // Template (default: AccessLogCombined)
+	File       FileSinkConfig  // Tulis ke file dengan rotasi (Path kosong = tidak ke file); Formatter diisi Format
+	Writer     io.Writer       // Output lain, misalnya os.Stdout (default: os.Stdout jika File.Path kosong)
+	Sinks      []SinkConfig    // Sink tambahan dengan formatter sendiri
+	BufferSize int             // Buffer size untuk async channel (default: 1000)
+	Redactor   *Redactor       // Redaction untuk URI, referer dan user agent (nil = tanpa redaction)
+}
+
+// NewAccessLogger creates a Logger that writes access log lines, untuk MiddlewareConfig.AccessLogger
+//
+//	accessLogger, err := logger.NewAccessLogger(logger.AccessLogConfig{
+//		Format: logger.AccessLogCombined,
+//		File:   logger.FileSinkConfig{Path: "access.log", RotateInterval: logger.RotateDaily},
+//	})
+func NewAccessLogger(config AccessLogConfig) (*Logger, error) {
+	format := config.Format
+	if format == "" {
+		format = AccessLogCombined
+	}
+
+	var sinks []SinkConfig
+	if config.File.Path != "" {
+		fileConfig := config.File
+		fileConfig.Formatter = format
+		file, err := NewFileSink(fileConfig)
+		if err != nil {
+			return nil, fmt.Errorf("failed to open access log file: %w", err)
+		}
+		sinks = append(sinks, SinkConfig{Sink: file})
+	}
+	writer := config.Writer
+	if writer == nil && config.File.Path == "" && len(config.Sinks) == 0 {
+		writer = os.Stdout
+	}
+	if writer != nil {
+		sinks = append(sinks, SinkConfig{Sink: NewWriterSink(writer, format)})
+	}
+	sinks = append(sinks, config.Sinks...)
+
+	return StartLogger(&LoggerConfig{
+		BufferSize: config.BufferSize,
+		Sinks:      sinks,
+		Redactor:   config.Redactor,
+		// Summary log yang di-drop bukan baris access log, cek dengan Dropped()
+		OverflowReportInterval: -1,
+	})
+}
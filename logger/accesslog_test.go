@@ -0,0 +1,125 @@
+package logger_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/funxdofficial/golang-module-syslog/logger"
+	"github.com/funxdofficial/golang-module-syslog/logger/logtest"
+)
+
+// accessStart is the fixed request time of the golden access log lines
+var accessStart = time.Date(2024, 3, 5, 14, 7, 9, 0, time.FixedZone("", 7*60*60))
+
+// newAccessLogger returns an access logger writing to a buffer, closed with t.Cleanup
+func newAccessLogger(t *testing.T, format logger.AccessLogFormat) (*logger.Logger, *lockedBuffer) {
+	t.Helper()
+	out := &lockedBuffer{}
+	accessLogger, err := logger.NewAccessLogger(logger.AccessLogConfig{Format: format, Writer: out})
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { accessLogger.Close() })
+	return accessLogger, out
+}
+
+// knownRequest is the request of the golden access log lines
+func knownRequest() *http.Request {
+	r := httptest.NewRequest(http.MethodGet, "/orders?id=7", nil)
+	r.RemoteAddr = "192.0.2.10:54321"
+	r.SetBasicAuth("alice", "secret")
+	r.Header.Set("Referer", "https://example.com/cart")
+	r.Header.Set("User-Agent", "curl/8.0")
+	return r
+}
+
+func TestAccessLogGolden(t *testing.T) {
+	tests := []struct {
+		name   string
+		format logger.AccessLogFormat
+		modify func(info *logger.AccessLogInfo)
+		want   string
+	}{
+		{
+			name:   "common",
+			format: logger.AccessLogCommon,
+			want:   `192.0.2.10 - alice [05/Mar/2024:14:07:09 +0700] "GET /orders?id=7 HTTP/1.1" 200 512`,
+		},
+		{
+			name:   "combined",
+			format: logger.AccessLogCombined,
+			want:   `192.0.2.10 - alice [05/Mar/2024:14:07:09 +0700] "GET /orders?id=7 HTTP/1.1" 200 512 "https://example.com/cart" "curl/8.0"`,
+		},
+		{
+			// Nilai kosong ditulis "-", seperti Apache
+			name:   "combined with empty values",
+			format: logger.AccessLogCombined,
+			modify: func(info *logger.AccessLogInfo) {
+				info.User, info.Referer, info.UserAgent = "", "", ""
+				info.Status, info.Bytes = http.StatusNoContent, 0
+			},
+			want: `192.0.2.10 - - [05/Mar/2024:14:07:09 +0700] "GET /orders?id=7 HTTP/1.1" 204 - "-" "-"`,
+		},
+		{
+			name:   "quotes are escaped",
+			format: logger.AccessLogCombined,
+			modify: func(info *logger.AccessLogInfo) {
+				info.UserAgent = `evil"agent`
+			},
+			want: `192.0.2.10 - alice [05/Mar/2024:14:07:09 +0700] "GET /orders?id=7 HTTP/1.1" 200 512 "https://example.com/cart" "evil\"agent"`,
+		},
+		{
+			name:   "custom template",
+			format: `{method} {uri} {status} {duration} {unknown}`,
+			want:   `GET /orders?id=7 200 1.500ms {unknown}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			accessLogger, out := newAccessLogger(t, tt.format)
+
+			info := logger.NewAccessLogInfo(knownRequest(), http.StatusOK, 512)
+			info.StartTime = accessStart
+			info.Duration = 1500 * time.Microsecond
+			if tt.modify != nil {
+				tt.modify(&info)
+			}
+			accessLogger.LogAccess(context.Background(), info)
+			if err := accessLogger.Flush(context.Background()); err != nil {
+				t.Fatal(err)
+			}
+
+			if got := strings.TrimSuffix(out.String(), "\n"); got != tt.want {
+				t.Errorf("access log mismatch\n got: %s\nwant: %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAccessLogFromMiddleware(t *testing.T) {
+	accessLogger, out := newAccessLogger(t, logger.AccessLogCombined)
+	// Start time di context berasal dari clock application logger
+	appLogger, _ := logtest.NewWithConfig(t, &logger.LoggerConfig{
+		MinLevel: logger.LevelInfo,
+		Clock:    logtest.NewStepClock(accessStart, time.Millisecond),
+	})
+
+	handler := appLogger.StandardHTTPMiddleware(logger.MiddlewareConfig{AccessLogger: accessLogger})(
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusCreated)
+			w.Write([]byte("created"))
+		}))
+	handler.ServeHTTP(httptest.NewRecorder(), knownRequest())
+	if err := accessLogger.Flush(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	want := `192.0.2.10 - alice [05/Mar/2024:14:07:09 +0700] "GET /orders?id=7 HTTP/1.1" 201 7 "https://example.com/cart" "curl/8.0"`
+	if got := strings.TrimSuffix(out.String(), "\n"); got != want {
+		t.Errorf("access log mismatch\n got: %s\nwant: %s", got, want)
+	}
+}
@@ -0,0 +1,178 @@
+package logger
+
+import (
+	"bytes"
+	"io"
+	"mime"
+	"net/http"
+	"strings"
+)
+
+// defaultMaxBodyBytes is the default limit of a body written to a log entry
+const defaultMaxBodyBytes = 4096
+
+// truncatedMarker is appended to a body that is longer than the limit
+const truncatedMarker = "...(truncated)"
+
+// DefaultSkipBodyContentTypes are the content types whose body is never captured by default:
+// binary, multipart dan streaming. Entry yang diakhiri "/" dicocokkan sebagai prefix.
+var DefaultSkipBodyContentTypes = []string{
+	"multipart/",
+	"text/event-stream",
+	"application/octet-stream",
+	"application/grpc",
+	"application/zip",
+	"application/gzip",
+	"application/pdf",
+	"image/",
+	"audio/",
+	"video/",
+	"font/",
+}
+
+// matchContentType reports whether the media type of contentType matches one of patterns
+func matchContentType(contentType string, patterns []string) bool {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		mediaType = strings.ToLower(strings.TrimSpace(contentType))
+	}
+	for _, pattern := range patterns {
+		pattern = strings.ToLower(pattern)
+		if strings.HasSuffix(pattern, "/") {
+			if strings.HasPrefix(mediaType, pattern) {
+				return true
+			}
+		} else if mediaType == pattern || strings.HasPrefix(mediaType, pattern+";") {
+			return true
+		}
+	}
+	return false
+}
+
+// maxBodyBytes returns MaxBodyBytes or the default
+func (c MiddlewareConfig) maxBodyBytes() int {
+	if c.MaxBodyBytes > 0 {
+		return c.MaxBodyBytes
+	}
+	return defaultMaxBodyBytes
+}
+
+// captureBodyFor reports whether a body of the given path and content type may be written to the log
+func (c MiddlewareConfig) captureBodyFor(path string, contentType string) bool {
+	for _, skipPath := range c.SkipBodyPaths {
+		if path == skipPath {
+			return false
+		}
+	}
+	if len(c.BodyContentTypes) > 0 {
+		return contentType != "" && matchContentType(contentType, c.BodyContentTypes)
+	}
+	deny := c.SkipBodyContentTypes
+	if deny == nil {
+		deny = DefaultSkipBodyContentTypes
+	}
+	return !matchContentType(contentType, deny)
+}
+
+// ReadRequestBody returns up to MaxBodyBytes of the request body for the START entry.
+// Body di-peek dan r.Body diganti sehingga handler tetap membaca body lengkap dari awal.
+// Mengembalikan "" jika CaptureRequestBody nonaktif atau content type / path di-skip.
+func (c MiddlewareConfig) ReadRequestBody(r *http.Request) string {
+	if !c.CaptureRequestBody || r.Body == nil || r.Body == http.NoBody {
+		return ""
+	}
+	if !c.captureBodyFor(r.URL.Path, r.Header.Get("Content-Type")) {
+		return ""
+	}
+	var body string
+	body, r.Body = peekBody(r.Body, c.maxBodyBytes())
+	return c.Redactor.RedactBody(body)
+}
+
+// BodyCapture is a bounded tee buffer for a response body.
+// Semua write dicatat sampai batas MaxBodyBytes, sehingga response chunked atau
+// multi-write tetap tercatat dari awal. Method-nya aman dipanggil pada nil.
+type BodyCapture struct {
+	config    MiddlewareConfig
+	path      string
+	max       int
+	buf       []byte
+	truncated bool
+	decided   bool
+	enabled   bool
+}
+
+// NewResponseCapture creates a capture for the response of path,
+// atau nil jika SkipResponseBody aktif
+func (c MiddlewareConfig) NewResponseCapture(path string) *BodyCapture {
+	if c.SkipResponseBody {
+		return nil
+	}
+	return &BodyCapture{config: c, path: path, max: c.maxBodyBytes()}
+}
+
+// Capture records p, deciding on the first write whether the content type may be captured.
+// Jika Content-Type belum di-set, dideteksi dari isi seperti net/http.
+func (b *BodyCapture) Capture(header http.Header, p []byte) {
+	if b == nil || len(p) == 0 {
+		return
+	}
+	if !b.decided {
+		b.decided = true
+		contentType := header.Get("Content-Type")
+		if contentType == "" {
+			contentType = http.DetectContentType(p)
+		}
+		b.enabled = b.config.captureBodyFor(b.path, contentType)
+	}
+	if !b.enabled || b.truncated {
+		return
+	}
+	if room := b.max - len(b.buf); len(p) > room {
+		b.buf = append(b.buf, p[:room]...)
+		b.truncated = true
+		return
+	}
+	b.buf = append(b.buf, p...)
+}
+
+// String returns the redacted captured body, with a truncation marker if it exceeded the limit
+func (b *BodyCapture) String() string {
+	if b == nil {
+		return ""
+	}
+	body := b.config.Redactor.RedactBody(string(b.buf))
+	if b.truncated {
+		return body + truncatedMarker
+	}
+	return body
+}
+
+// peekBody reads up to max bytes of body for logging and returns a body that still
+// yields the full, unconsumed content
+func peekBody(body io.ReadCloser, max int) (string, io.ReadCloser) {
+	buf, err := io.ReadAll(io.LimitReader(body, int64(max)+1))
+	rest := io.MultiReader(bytes.NewReader(buf), body)
+	if err != nil {
+		// Error dikembalikan lagi ke pembaca body setelah bagian yang sudah terbaca
+		rest = io.MultiReader(bytes.NewReader(buf), errReader{err})
+	}
+	restored := struct {
+		io.Reader
+		io.Closer
+	}{rest, body}
+
+	if len(buf) > max {
+		return string(buf[:max]) + truncatedMarker, restored
+	}
+	return string(buf), restored
+}
+
+// errReader returns err on every Read
+type errReader struct {
+	err error
+}
+
+func (r errReader) Read([]byte) (int, error) {
+	return 0, r.err
+}
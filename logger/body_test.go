@@ -0,0 +1,139 @@
+package logger_test
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/funxdofficial/golang-module-syslog/logger"
+	"github.com/funxdofficial/golang-module-syslog/logger/logtest"
+)
+
+func TestRequestBodyTruncatedInLogButFullForHandler(t *testing.T) {
+	appLogger, logs := logtest.New(t)
+	body := strings.Repeat("a", 10) + strings.Repeat("b", 90)
+
+	var read string
+	handler := appLogger.StandardHTTPMiddleware(logger.MiddlewareConfig{
+		CaptureRequestBody: true,
+		MaxBodyBytes:       10,
+	})(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		b, err := io.ReadAll(r.Body)
+		if err != nil {
+			t.Errorf("handler failed to read body: %v", err)
+		}
+		read = string(b)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+
+	req := httptest.NewRequest(http.MethodPost, "/orders", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Request-ID", "req-body")
+	handler.ServeHTTP(httptest.NewRecorder(), req)
+
+	if read != body {
+		t.Errorf("handler read %d bytes, want the full %d byte body", len(read), len(body))
+	}
+	start := logs.AssertTransaction(t, "req-body").Flag(logger.FlagStart)[0]
+	if want := strings.Repeat("a", 10) + "...(truncated)"; start.Body != want {
+		t.Errorf("START body = %q, want %q", start.Body, want)
+	}
+}
+
+func TestReadRequestBody(t *testing.T) {
+	tests := []struct {
+		name        string
+		config      logger.MiddlewareConfig
+		path        string // "" = /orders
+		contentType string
+		body        string
+		wantLogged  string
+	}{
+		{"disabled", logger.MiddlewareConfig{}, "", "application/json", `{"a":1}`, ""},
+		{"within limit", logger.MiddlewareConfig{CaptureRequestBody: true, MaxBodyBytes: 7}, "", "application/json", `{"a":1}`, `{"a":1}`},
+		{"over limit", logger.MiddlewareConfig{CaptureRequestBody: true, MaxBodyBytes: 3}, "", "text/plain", "abcdef", "abc...(truncated)"},
+		{"skipped content type", logger.MiddlewareConfig{CaptureRequestBody: true}, "", "multipart/form-data; boundary=x", "--x--", ""},
+		{"allow list", logger.MiddlewareConfig{CaptureRequestBody: true, BodyContentTypes: []string{"application/json"}}, "", "text/plain", "abc", ""},
+		{"skipped path", logger.MiddlewareConfig{CaptureRequestBody: true, SkipBodyPaths: []string{"/login"}}, "/login", "application/json", `{"password":"x"}`, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := tt.path
+			if path == "" {
+				path = "/orders"
+			}
+			req := httptest.NewRequest(http.MethodPost, path, strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", tt.contentType)
+
+			if got := tt.config.ReadRequestBody(req); got != tt.wantLogged {
+				t.Errorf("logged body = %q, want %q", got, tt.wantLogged)
+			}
+			// Body untuk handler selalu lengkap, apa pun hasil capture
+			rest, err := io.ReadAll(req.Body)
+			if err != nil || string(rest) != tt.body {
+				t.Errorf("handler body = %q (%v), want %q", rest, err, tt.body)
+			}
+		})
+	}
+}
+
+// failingBody returns data followed by err
+type failingBody struct {
+	r   io.Reader
+	err error
+}
+
+func (b *failingBody) Read(p []byte) (int, error) {
+	n, err := b.r.Read(p)
+	if err == io.EOF {
+		return n, b.err
+	}
+	return n, err
+}
+
+func (b *failingBody) Close() error { return nil }
+
+func TestReadRequestBodyKeepsReadError(t *testing.T) {
+	errReset := errors.New("connection reset")
+	req := httptest.NewRequest(http.MethodPost, "/orders", nil)
+	req.Header.Set("Content-Type", "text/plain")
+	req.Body = &failingBody{r: strings.NewReader("partial"), err: errReset}
+
+	config := logger.MiddlewareConfig{CaptureRequestBody: true}
+	if got := config.ReadRequestBody(req); got != "partial" {
+		t.Errorf("logged body = %q, want partial", got)
+	}
+	rest, err := io.ReadAll(req.Body)
+	if string(rest) != "partial" || !errors.Is(err, errReset) {
+		t.Errorf("handler read %q, %v; want partial, %v", rest, err, errReset)
+	}
+}
+
+func TestResponseCaptureTruncatesAcrossWrites(t *testing.T) {
+	capture := logger.MiddlewareConfig{MaxBodyBytes: 8}.NewResponseCapture("/orders")
+	header := http.Header{"Content-Type": []string{"application/json"}}
+	capture.Capture(header, []byte("12345"))
+	capture.Capture(header, []byte("67890"))
+	capture.Capture(header, []byte("abc"))
+
+	if got, want := capture.String(), "12345678...(truncated)"; got != want {
+		t.Errorf("captured = %q, want %q", got, want)
+	}
+
+	// Content type yang di-skip tidak di-capture sama sekali
+	binary := logger.MiddlewareConfig{}.NewResponseCapture("/download")
+	binary.Capture(http.Header{"Content-Type": []string{"application/octet-stream"}}, []byte("data"))
+	if got := binary.String(); got != "" {
+		t.Errorf("captured binary body = %q, want empty", got)
+	}
+
+	// SkipResponseBody mengembalikan nil capture yang aman dipakai
+	var none *logger.BodyCapture = logger.MiddlewareConfig{SkipResponseBody: true}.NewResponseCapture("/orders")
+	none.Capture(header, []byte("data"))
+	if got := none.String(); got != "" {
+		t.Errorf("nil capture = %q, want empty", got)
+	}
+}
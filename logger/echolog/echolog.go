@@ -0,0 +1,153 @@
+// Package echolog provides the request logging middleware of package logger for Echo.
+package echolog
+
+import (
+	"context"
+	"net/http"
+	"runtime/debug"
+
+	"github.com/funxdofficial/golang-module-syslog/logger"
+	"github.com/labstack/echo/v4"
+)
+
+// RequestInfo implements logger.HTTPRequestInfo for Echo
+type RequestInfo struct {
+	c    echo.Context
+	body string // Request body yang di-capture oleh middleware
+}
+
+// NewRequestInfo wraps an Echo context as logger.HTTPRequestInfo
+func NewRequestInfo(c echo.Context) *RequestInfo {
+	return &RequestInfo{c: c}
+}
+
+func (r *RequestInfo) Method() string {
+	return r.c.Request().Method
+}
+
+func (r *RequestInfo) Path() string {
+	return r.c.Request().URL.Path
+}
+
+func (r *RequestInfo) Body() string {
+	// Body di-capture oleh Middleware (lihat MiddlewareConfig.CaptureRequestBody)
+	return r.body
+}
+
+func (r *RequestInfo) Header(key string) string {
+	return r.c.Request().Header.Get(key)
+}
+
+func (r *RequestInfo) Context() context.Context {
+	return r.c.Request().Context()
+}
+
+// Middleware logs START and STOP for every request, seperti logger.StandardHTTPMiddleware.
+// Endpoint berisi route template (c.Path(), misalnya "/users/:id") dan path asli ditulis
+// sebagai field "path". Entry STOP berisi status, ukuran response dan error dari handler.
+// Error dari handler sudah ditangani dengan c.Error (HTTPErrorHandler) sebelum STOP ditulis,
+// sehingga middleware mengembalikan nil agar HTTPErrorHandler tidak dipanggil dua kali.
+func Middleware(l *logger.Logger, config logger.MiddlewareConfig) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) (err error) {
+			req := c.Request()
+
+			// Skip paths jika ada
+			if config.ShouldSkip(req.URL.Path) {
+				return next(c)
+			}
+
+			// Route template dari router Echo
+			route := c.Path()
+			if route != "" && route != req.URL.Path {
+				c.SetRequest(req.WithContext(logger.WithFields(req.Context(), logger.String("path", req.URL.Path))))
+			}
+
+			// Start logging
+			startConfig := logger.StartConfig{
+				ServiceName: config.ServiceName,
+				Endpoint:    route,
+				Propagation: config.Propagation,
+			}
+			reqInfo := &RequestInfo{c: c, body: config.ReadRequestBody(c.Request())}
+			ctx := l.StartFromHTTPRequestInfo(reqInfo, startConfig)
+
+			// Echo correlation IDs agar client / gateway bisa mencocokkan log
+			if !config.SkipResponseHeaders {
+				logger.SetCorrelationHeaders(c.Response().Header(), logger.CorrelationFromContext(ctx))
+			}
+
+			// Update context dan wrap writer untuk capture response body
+			c.SetRequest(c.Request().WithContext(ctx))
+			writer := &bodyWriter{ResponseWriter: c.Response().Writer, body: config.NewResponseCapture(req.URL.Path)}
+			c.Response().Writer = writer
+
+			// Recover panic dari handler dan tulis ERROR STOP
+			if config.RecoverPanic {
+				defer func() {
+					if rec := recover(); rec != nil {
+						stack := debug.Stack()
+						if rec != http.ErrAbortHandler && !c.Response().Committed {
+							config.WritePanicResponse(c.Response())
+						}
+						fields := []logger.Field{
+							logger.Int("status", c.Response().Status),
+							logger.Int64("bytes", c.Response().Size),
+						}
+						l.StopPanic(logger.WithFields(ctx, fields...), rec, stack, writer.body.String())
+						if config.AccessLogger != nil {
+							config.AccessLogger.LogAccess(ctx, logger.NewAccessLogInfo(c.Request(), c.Response().Status, c.Response().Size))
+						}
+						if config.ShouldRepanic(rec) {
+							panic(rec)
+						}
+					}
+				}()
+			}
+
+			// Process request
+			err = next(c)
+			if err != nil {
+				// Jalankan HTTPErrorHandler sekarang agar status response sudah final
+				c.Error(err)
+			}
+
+			// Stop logging
+			status := c.Response().Status
+			level := logger.LevelForStatus(status)
+			fields := []logger.Field{
+				logger.Int("status", status),
+				logger.Int64("bytes", c.Response().Size),
+			}
+			if err != nil {
+				level = "ERROR"
+				fields = append(fields, logger.Err(err))
+			}
+
+			l.Stop(logger.WithFields(ctx, fields...), level, "Request completed", writer.body.String())
+			if config.AccessLogger != nil {
+				config.AccessLogger.LogAccess(ctx, logger.NewAccessLogInfo(c.Request(), status, c.Response().Size))
+			}
+
+			// Error sudah ditangani oleh c.Error di atas
+			return nil
+		}
+	}
+}
+
+// bodyWriter wraps http.ResponseWriter untuk capture response body.
+// Flush dan Hijack tetap bekerja lewat Unwrap (echo.Response memakai http.ResponseController).
+type bodyWriter struct {
+	http.ResponseWriter
+	body *logger.BodyCapture
+}
+
+func (w *bodyWriter) Write(b []byte) (int, error) {
+	n, err := w.ResponseWriter.Write(b)
+	w.body.Capture(w.Header(), b[:n])
+	return n, err
+}
+
+func (w *bodyWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
@@ -0,0 +1,135 @@
+package echolog_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/funxdofficial/golang-module-syslog/logger"
+	"github.com/funxdofficial/golang-module-syslog/logger/echolog"
+	"github.com/funxdofficial/golang-module-syslog/logger/logtest"
+	"github.com/labstack/echo/v4"
+)
+
+func newServer(t *testing.T, config logger.MiddlewareConfig) (*echo.Echo, *logtest.Observer) {
+	t.Helper()
+	appLogger, logs := logtest.New(t)
+
+	e := echo.New()
+	e.Use(echolog.Middleware(appLogger, config))
+	e.GET("/users/:id", func(c echo.Context) error {
+		return c.JSON(http.StatusOK, map[string]string{"id": c.Param("id")})
+	})
+	e.POST("/users", func(c echo.Context) error {
+		return echo.NewHTTPError(http.StatusBadRequest, "validation failed")
+	})
+	e.GET("/fail", func(c echo.Context) error {
+		return errors.New("database unavailable")
+	})
+	e.GET("/panic", func(c echo.Context) error {
+		panic("boom")
+	})
+	return e, logs
+}
+
+func TestMiddlewareStartStop(t *testing.T) {
+	e, logs := newServer(t, logger.MiddlewareConfig{ServiceName: "user-service"})
+
+	req := httptest.NewRequest(http.MethodGet, "/users/42", nil)
+	req.Header.Set("X-Request-ID", "req-123")
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, want 200", rec.Code)
+	}
+	if got := rec.Header().Get("X-Request-ID"); got != "req-123" {
+		t.Errorf("response X-Request-ID = %q, want req-123", got)
+	}
+
+	entries := logs.AssertTransaction(t, "req-123")
+	for _, entry := range entries {
+		if entry.Endpoint != "/users/:id" || entry.MethodType != http.MethodGet || entry.ServiceName != "user-service" {
+			t.Errorf("%s entry: endpoint=%s method=%s service=%s", entry.Flag, entry.Endpoint, entry.MethodType, entry.ServiceName)
+		}
+		if path, _ := entry.Field("path"); path != "/users/42" {
+			t.Errorf("%s entry: field path = %v, want /users/42", entry.Flag, path)
+		}
+	}
+
+	stop := entries.Flag(logger.FlagStop)[0]
+	if stop.Level() != logger.LevelSuccess {
+		t.Errorf("STOP level = %s, want SUCCESS", stop.Level())
+	}
+	if status, _ := stop.Field("status"); status != int64(http.StatusOK) {
+		t.Errorf("STOP status = %v, want 200", status)
+	}
+	if bytes, _ := stop.Field("bytes"); bytes != int64(rec.Body.Len()) {
+		t.Errorf("STOP bytes = %v, want %d", bytes, rec.Body.Len())
+	}
+}
+
+func TestMiddlewareHandlerErrors(t *testing.T) {
+	tests := []struct {
+		name   string
+		method string
+		path   string
+		status int
+	}{
+		{"HTTPError", http.MethodPost, "/users", http.StatusBadRequest},
+		{"plain error", http.MethodGet, "/fail", http.StatusInternalServerError},
+		{"no route", http.MethodGet, "/missing", http.StatusNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e, logs := newServer(t, logger.MiddlewareConfig{})
+
+			req := httptest.NewRequest(tt.method, tt.path, nil)
+			req.Header.Set("X-Request-ID", "req-err")
+			rec := httptest.NewRecorder()
+			e.ServeHTTP(rec, req)
+
+			// Status response sudah final (HTTPErrorHandler dipanggil sekali) saat STOP ditulis
+			if rec.Code != tt.status {
+				t.Errorf("status = %d, want %d", rec.Code, tt.status)
+			}
+			stop := logs.AssertTransaction(t, "req-err").Flag(logger.FlagStop)[0]
+			if stop.Level() != logger.LevelError {
+				t.Errorf("STOP level = %s, want ERROR", stop.Level())
+			}
+			if status, _ := stop.Field("status"); status != int64(tt.status) {
+				t.Errorf("STOP status = %v, want %d", status, tt.status)
+			}
+			if _, ok := stop.Field("error"); !ok {
+				t.Errorf("STOP entry has no error field: %v", stop.Fields)
+			}
+		})
+	}
+}
+
+func TestMiddlewareRecoverPanic(t *testing.T) {
+	e, logs := newServer(t, logger.MiddlewareConfig{RecoverPanic: true})
+
+	req := httptest.NewRequest(http.MethodGet, "/panic", nil)
+	req.Header.Set("X-Request-ID", "req-panic")
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusInternalServerError {
+		t.Errorf("status = %d, want 500", rec.Code)
+	}
+	stop := logs.AssertTransaction(t, "req-panic").Flag(logger.FlagStop)[0]
+	if stop.Level() != logger.LevelError {
+		t.Errorf("STOP level = %s, want ERROR", stop.Level())
+	}
+}
+
+func TestMiddlewareSkipPaths(t *testing.T) {
+	e, logs := newServer(t, logger.MiddlewareConfig{SkipPaths: []string{"/users/1"}})
+
+	e.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/users/1", nil))
+	if logs.Len() != 0 {
+		t.Errorf("got %d entries for a skipped path, want 0", logs.Len())
+	}
+}
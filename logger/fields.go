@@ -0,0 +1,217 @@
+package logger
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// FieldType represents the type of a structured field value
+type FieldType int
+
+const (
+	FieldString FieldType = iota + 1
+	FieldInt
+	FieldDuration
+	FieldError
+	FieldBool
+	FieldAny // Value lain, ditulis dengan fmt %v
+)
+
+// FieldsKey is the key for storing structured fields in context
+const FieldsKey ContextKey = "logger_fields"
+
+// Field is a typed key-value pair attached to a log entry
+type Field struct {
+	Key   string
+	Type  FieldType
+	Value interface{}
+}
+
+// String creates a string field
+func String(key string, value string) Field {
+	return Field{Key: key, Type: FieldString, Value: value}
+}
+
+// Int creates an integer field
+func Int(key string, value int) Field {
+	return Field{Key: key, Type: FieldInt, Value: int64(value)}
+}
+
+// Int64 creates an integer field
+func Int64(key string, value int64) Field {
+	return Field{Key: key, Type: FieldInt, Value: value}
+}
+
+// Duration creates a duration field
+func Duration(key string, value time.Duration) Field {
+	return Field{Key: key, Type: FieldDuration, Value: value}
+}
+
+// Err creates an error field with key "error"
+func Err(err error) Field {
+	return NamedErr("error", err)
+}
+
+// NamedErr creates an error field with a custom key
+func NamedErr(key string, err error) Field {
+	return Field{Key: key, Type: FieldError, Value: err}
+}
+
+// Bool creates a boolean field
+func Bool(key string, value bool) Field {
+	return Field{Key: key, Type: FieldBool, Value: value}
+}
+
+// Any creates a field, choosing the type from the value
+func Any(key string, value interface{}) Field {
+	switch v := value.(type) {
+	case Field:
+		return v
+	case string:
+		return String(key, v)
+	case int:
+		return Int(key, v)
+	case int8:
+		return Int64(key, int64(v))
+	case int16:
+		return Int64(key, int64(v))
+	case int32:
+		return Int64(key, int64(v))
+	case int64:
+		return Int64(key, v)
+	case uint8:
+		return Int64(key, int64(v))
+	case uint16:
+		return Int64(key, int64(v))
+	case uint32:
+		return Int64(key, int64(v))
+	case time.Duration:
+		return Duration(key, v)
+	case error:
+		return NamedErr(key, v)
+	case bool:
+		return Bool(key, v)
+	}
+	return Field{Key: key, Type: FieldAny, Value: value}
+}
+
+// ValueString returns the field value as text (dipakai oleh format text, logfmt dan syslog).
+// Field yang dibuat manual dengan Type yang tidak cocok dengan Value ditulis dengan fmt %v,
+// bukan panic di goroutine worker.
+func (f Field) ValueString() string {
+	switch f.Type {
+	case FieldString:
+		if v, ok := f.Value.(string); ok {
+			return v
+		}
+	case FieldInt:
+		if v, ok := f.Value.(int64); ok {
+			return strconv.FormatInt(v, 10)
+		}
+	case FieldDuration:
+		if v, ok := f.Value.(time.Duration); ok {
+			return v.String()
+		}
+	case FieldError:
+		if v, ok := f.Value.(error); ok {
+			return v.Error()
+		}
+	case FieldBool:
+		if v, ok := f.Value.(bool); ok {
+			return strconv.FormatBool(v)
+		}
+	}
+	return fmt.Sprint(f.Value)
+}
+
+// jsonValue returns the field value for the JSON encoder: angka dan bool tetap bertipe,
+// duration dan error ditulis sebagai string
+func (f Field) jsonValue() interface{} {
+	switch f.Type {
+	case FieldInt:
+		if v, ok := f.Value.(int64); ok {
+			return v
+		}
+		return f.ValueString()
+	case FieldBool:
+		if v, ok := f.Value.(bool); ok {
+			return v
+		}
+		return f.ValueString()
+	case FieldDuration, FieldError:
+		return f.ValueString()
+	}
+	return f.Value
+}
+
+// fieldsFromArgs converts alternating key/value arguments (atau Field) into fields.
+// Key yang bukan string ditulis dengan fmt %v, dan key tanpa value diberi value "!MISSING".
+func fieldsFromArgs(args []interface{}) []Field {
+	fields := make([]Field, 0, len(args)/2)
+	for i := 0; i < len(args); i++ {
+		if f, ok := args[i].(Field); ok {
+			fields = append(fields, f)
+			continue
+		}
+		key, ok := args[i].(string)
+		if !ok {
+			key = fmt.Sprintf("%v", args[i])
+		}
+		if i+1 >= len(args) {
+			fields = append(fields, String(key, "!MISSING"))
+			break
+		}
+		fields = append(fields, Any(key, args[i+1]))
+		i++
+	}
+	return fields
+}
+
+// With returns a child logger that adds the given fields to every entry.
+// Argumen berupa pasangan key/value atau Field, misalnya With("user_id", 42, logger.Err(err)).
+// Child logger berbagi worker, channel dan sink dengan parent-nya.
+func (l *Logger) With(args ...interface{}) *Logger {
+	fields := fieldsFromArgs(args)
+	if len(fields) == 0 {
+		return l
+	}
+	return &Logger{
+		loggerCore: l.loggerCore,
+		fields:     append(append([]Field(nil), l.fields...), fields...),
+	}
+}
+
+// WithFields adds structured fields to context.
+// Semua *Ctx call dan LogWithMandatoryFields dengan context ini akan menulis fields tersebut.
+func WithFields(ctx context.Context, fields ...Field) context.Context {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	existing := getFieldsFromContext(ctx)
+	return context.WithValue(ctx, FieldsKey, append(append([]Field(nil), existing...), fields...))
+}
+
+// getFieldsFromContext extracts structured fields from context
+func getFieldsFromContext(ctx context.Context) []Field {
+	if ctx == nil {
+		return nil
+	}
+	if fields, ok := ctx.Value(FieldsKey).([]Field); ok {
+		return fields
+	}
+	return nil
+}
+
+// entryFields returns the logger fields followed by the context fields
+func (l *Logger) entryFields(ctx context.Context) []Field {
+	ctxFields := getFieldsFromContext(ctx)
+	if len(ctxFields) == 0 {
+		return l.fields
+	}
+	if len(l.fields) == 0 {
+		return ctxFields
+	}
+	return append(append([]Field(nil), l.fields...), ctxFields...)
+}
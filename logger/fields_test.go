@@ -0,0 +1,110 @@
+package logger_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/funxdofficial/golang-module-syslog/logger"
+	"github.com/funxdofficial/golang-module-syslog/logger/logtest"
+)
+
+func TestFieldValueString(t *testing.T) {
+	tests := []struct {
+		name  string
+		field logger.Field
+		want  string
+	}{
+		{"string", logger.String("k", "v"), "v"},
+		{"int", logger.Int("k", -42), "-42"},
+		{"int64", logger.Int64("k", 1<<40), "1099511627776"},
+		{"duration", logger.Duration("k", 1500*time.Millisecond), "1.5s"},
+		{"error", logger.Err(errors.New("boom")), "boom"},
+		{"nil error", logger.Err(nil), "<nil>"},
+		{"bool", logger.Bool("k", true), "true"},
+		{"any struct", logger.Any("k", struct{ A int }{1}), "{1}"},
+		{"any uint8", logger.Any("k", uint8(7)), "7"},
+		{"any duration", logger.Any("k", time.Second), "1s"},
+
+		// Field manual dengan Type yang tidak cocok tidak boleh panic
+		{"string type with int value", logger.Field{Key: "k", Type: logger.FieldString, Value: 42}, "42"},
+		{"int type with string value", logger.Field{Key: "k", Type: logger.FieldInt, Value: "x"}, "x"},
+		{"int type with int value", logger.Field{Key: "k", Type: logger.FieldInt, Value: 7}, "7"},
+		{"duration type with nil value", logger.Field{Key: "k", Type: logger.FieldDuration}, "<nil>"},
+		{"error type with string value", logger.Field{Key: "k", Type: logger.FieldError, Value: "oops"}, "oops"},
+		{"bool type with string value", logger.Field{Key: "k", Type: logger.FieldBool, Value: "yes"}, "yes"},
+		{"zero type", logger.Field{Key: "k", Value: 1.5}, "1.5"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.field.ValueString(); got != tt.want {
+				t.Errorf("ValueString() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMismatchedFieldDoesNotPanicInWorker(t *testing.T) {
+	appLogger, logs := logtest.New(t)
+
+	bad := []logger.Field{
+		{Key: "s", Type: logger.FieldString, Value: 42},
+		{Key: "i", Type: logger.FieldInt, Value: "x"},
+		{Key: "b", Type: logger.FieldBool, Value: 1},
+	}
+	appLogger.With(bad[0], bad[1], bad[2]).Info("hand-built fields")
+
+	entry := logs.AssertLogged(t, logger.LevelInfo, "hand-built fields")
+	for _, format := range []logger.LogFormat{logger.FormatText, logger.FormatJSON, logger.FormatLogfmt} {
+		out := format.Format(&entry.LogEntry)
+		if strings.Contains(out, "failed to encode") {
+			t.Errorf("%s: %s", format, out)
+		}
+	}
+	if got := logger.FormatJSON.Format(&entry.LogEntry); !strings.Contains(got, `"fields":{"b":"1","i":"x","s":42}`) {
+		t.Errorf("json = %s", got)
+	}
+}
+
+func TestWithArgs(t *testing.T) {
+	appLogger, logs := logtest.New(t)
+
+	appLogger.With("user_id", 42, logger.Err(errors.New("denied")), 7, "x", "dangling").Info("args")
+
+	entry := logs.AssertLogged(t, logger.LevelInfo, "args")
+	want := []string{"user_id=42", "error=denied", "7=x", "dangling=!MISSING"}
+	var got []string
+	for _, f := range entry.Fields {
+		got = append(got, f.Key+"="+f.ValueString())
+	}
+	if strings.Join(got, " ") != strings.Join(want, " ") {
+		t.Errorf("fields = %v, want %v", got, want)
+	}
+	if appLogger.With() != appLogger {
+		t.Error("With without fields returns a new logger")
+	}
+}
+
+func TestLoggerAndContextFieldsOrder(t *testing.T) {
+	appLogger, logs := logtest.New(t)
+
+	child := appLogger.With("service", "payment")
+	ctx := logger.WithFields(context.Background(), logger.String("order_id", "ORD-1"))
+	ctx = logger.WithFields(ctx, logger.Int("attempt", 2))
+	child.InfoCtx(ctx, "charging")
+	appLogger.Info("parent")
+
+	entry := logs.AssertLogged(t, logger.LevelInfo, "charging")
+	var keys []string
+	for _, f := range entry.Fields {
+		keys = append(keys, f.Key)
+	}
+	if strings.Join(keys, ",") != "service,order_id,attempt" {
+		t.Errorf("field order = %v", keys)
+	}
+	if parent := logs.AssertLogged(t, logger.LevelInfo, "parent"); len(parent.Fields) != 0 {
+		t.Errorf("parent logger has child fields: %v", parent.Fields)
+	}
+}
@@ -0,0 +1,228 @@
+package logger
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// LogFormat represents the encoding of a log line
+type LogFormat string
+
+const (
+	FormatText   LogFormat = "text"   // Format human-readable (default)
+	FormatJSON   LogFormat = "json"   // Satu JSON object per baris
+	FormatLogfmt LogFormat = "logfmt" // key=value per baris
+)
+
+// timestampLayout is the layout of LogEntry.Timestamp
+const timestampLayout = "2006-01-02 15:04:05.000"
+
+// jsonTimeLayout is the RFC 3339 layout used by the JSON and logfmt encoders
+const jsonTimeLayout = "2006-01-02T15:04:05.000Z07:00"
+
+// jsonEntry defines the stable keys of the JSON encoder
+type jsonEntry struct {
+	Timestamp     string                 `json:"timestamp"`
+	Level         string                 `json:"level"`
+	Flag          string                 `json:"flag"`
+	Message       string                 `json:"message"`
+	UUID          string                 `json:"uuid"`
+	TransactionID string                 `json:"transaction_id"`
+	TraceID       string                 `json:"trace_id"`
+	SpanID        string                 `json:"span_id,omitempty"`
+	TraceFlags    string                 `json:"trace_flags,omitempty"`
+	ServiceName   string                 `json:"service_name"`
+	Endpoint      string                 `json:"endpoint"`
+	Method        string                 `json:"method"`
+	ExecutionTime string                 `json:"execution_time"`
+	Hostname      string                 `json:"hostname"`
+	ServerIP      string                 `json:"server_ip"`
+	PodName       string                 `json:"pod_name,omitempty"`
+	Namespace     string                 `json:"namespace,omitempty"`
+	NodeName      string                 `json:"node_name,omitempty"`
+	File          string                 `json:"file"`
+	Line          int                    `json:"line"`
+	Function      string                 `json:"function"`
+	Body          string                 `json:"body"`
+	Fields        map[string]interface{} `json:"fields"`
+}
+
+// parseLogFormat validates a format, returning fallback if empty
+func parseLogFormat(format LogFormat, fallback LogFormat) (LogFormat, error) {
+	switch format {
+	case "":
+		return fallback, nil
+	case FormatText, FormatJSON, FormatLogfmt:
+		return format, nil
+	}
+	return "", fmt.Errorf("unsupported log format '%s'", format)
+}
+
+// buildEntry converts a queued message into a LogEntry with every field filled in
+func (l *Logger) buildEntry(msg *logMessage) *LogEntry {
+	if msg.entry != nil {
+		entry := *msg.entry
+		l.host.apply(&entry)
+		return &entry
+	}
+
+	now := msg.time
+	if now.IsZero() {
+		now = l.now()
+	}
+	entry := &LogEntry{
+		Time:       now,
+		Timestamp:  now.Format(timestampLayout),
+		LogLevel:   msg.level,
+		UUID:       msg.uuid,
+		ServerIP:   l.host.IP,
+		TraceID:    msg.traceID,
+		SpanID:     msg.spanID,
+		TraceFlags: msg.traceFlags,
+		File:       msg.file,
+		Line:       msg.line,
+		Function:   msg.function,
+		Message:    fmt.Sprintf(msg.message, msg.args...),
+		Fields:     msg.fields,
+	}
+	l.host.apply(entry)
+	return entry
+}
+
+// Format encodes the entry using this format, so a LogFormat can be used as a Formatter
+func (f LogFormat) Format(entry *LogEntry) string {
+	switch f {
+	case FormatJSON:
+		return formatJSON(entry)
+	case FormatLogfmt:
+		return formatLogfmt(entry)
+	}
+	return formatText(entry)
+}
+
+// formatText encodes the entry in the human-readable layout.
+// Entry dari LogWithMandatoryFields selalu punya TransactionID dan memakai layout mandatory fields.
+func formatText(entry *LogEntry) string {
+	if entry.TransactionID != "" {
+		return formatMandatoryMessage(*entry)
+	}
+	return formatMessage(entry)
+}
+
+// formatJSON encodes the entry as a single-line JSON object
+func formatJSON(entry *LogEntry) string {
+	b, err := json.Marshal(jsonEntry{
+		Timestamp:     entry.Time.Format(jsonTimeLayout),
+		Level:         entry.LogLevel,
+		Flag:          string(entry.Flag),
+		Message:       entry.Message,
+		UUID:          entry.UUID,
+		TransactionID: entry.TransactionID,
+		TraceID:       entry.TraceID,
+		SpanID:        entry.SpanID,
+		TraceFlags:    entry.TraceFlags,
+		ServiceName:   entry.ServiceName,
+		Endpoint:      entry.Endpoint,
+		Method:        entry.MethodType,
+		ExecutionTime: entry.ExecutionTime,
+		Hostname:      entry.Hostname,
+		ServerIP:      entry.ServerIP,
+		PodName:       entry.PodName,
+		Namespace:     entry.Namespace,
+		NodeName:      entry.NodeName,
+		File:          entry.File,
+		Line:          entry.Line,
+		Function:      entry.Function,
+		Body:          entry.Body,
+		Fields:        jsonFields(entry.Fields),
+	})
+	if err != nil {
+		return fmt.Sprintf(`{"level":"ERROR","message":%q}`, "failed to encode log entry: "+err.Error())
+	}
+	return string(b)
+}
+
+// formatLogfmt encodes the entry as key=value pairs, skipping empty values
+func formatLogfmt(entry *LogEntry) string {
+	pairs := [][2]string{
+		{"timestamp", entry.Time.Format(jsonTimeLayout)},
+		{"level", entry.LogLevel},
+		{"flag", string(entry.Flag)},
+		{"message", entry.Message},
+		{"uuid", entry.UUID},
+		{"transaction_id", entry.TransactionID},
+		{"trace_id", entry.TraceID},
+		{"span_id", entry.SpanID},
+		{"trace_flags", entry.TraceFlags},
+		{"service_name", entry.ServiceName},
+		{"endpoint", entry.Endpoint},
+		{"method", entry.MethodType},
+		{"execution_time", entry.ExecutionTime},
+		{"hostname", entry.Hostname},
+		{"server_ip", entry.ServerIP},
+		{"pod_name", entry.PodName},
+		{"namespace", entry.Namespace},
+		{"node_name", entry.NodeName},
+		{"file", entry.File},
+		{"line", ""},
+		{"function", entry.Function},
+		{"body", entry.Body},
+	}
+	if entry.Line > 0 {
+		pairs[19][1] = strconv.Itoa(entry.Line)
+	}
+	for _, f := range entry.Fields {
+		pairs = append(pairs, [2]string{f.Key, f.ValueString()})
+	}
+
+	var b strings.Builder
+	for _, pair := range pairs {
+		if pair[1] == "" {
+			continue
+		}
+		if b.Len() > 0 {
+			b.WriteByte(' ')
+		}
+		b.WriteString(pair[0])
+		b.WriteByte('=')
+		b.WriteString(logfmtValue(pair[1]))
+	}
+	return b.String()
+}
+
+// logfmtValue quotes a value if it contains spaces, quotes, '=' or control characters
+func logfmtValue(value string) string {
+	for _, r := range value {
+		if r == ' ' || r == '=' || r == '"' || r == '\\' || unicode.IsControl(r) {
+			return strconv.Quote(value)
+		}
+	}
+	return value
+}
+
+// formatFields renders structured fields as key=value pairs for the text format
+func formatFields(fields []Field) string {
+	var b strings.Builder
+	for i, f := range fields {
+		if i > 0 {
+			b.WriteByte(' ')
+		}
+		b.WriteString(f.Key)
+		b.WriteByte('=')
+		b.WriteString(logfmtValue(f.ValueString()))
+	}
+	return b.String()
+}
+
+// jsonFields converts structured fields to the "fields" object of the JSON encoder.
+// Selalu non-nil agar key "fields" tetap stabil ({} jika tidak ada field).
+func jsonFields(fields []Field) map[string]interface{} {
+	m := make(map[string]interface{}, len(fields))
+	for _, f := range fields {
+		m[f.Key] = f.jsonValue()
+	}
+	return m
+}
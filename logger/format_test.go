@@ -0,0 +1,207 @@
+package logger_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/funxdofficial/golang-module-syslog/logger"
+)
+
+// goldenEntry returns an entry with a fixed time so encoder output is deterministic
+func goldenEntry() logger.LogEntry {
+	return logger.LogEntry{
+		Time:      time.Date(2024, 1, 2, 3, 4, 5, 6e6, time.UTC),
+		Timestamp: "2024-01-02 03:04:05.006",
+		LogLevel:  "INFO",
+		Message:   "hello",
+		UUID:      "u-1",
+		Hostname:  "host",
+		ServerIP:  "10.0.0.1",
+		File:      "main.go",
+		Line:      12,
+		Function:  "main.run",
+	}
+}
+
+func TestFormatJSONGolden(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(e *logger.LogEntry)
+		want   string
+	}{
+		{
+			name:   "minimal",
+			modify: func(e *logger.LogEntry) {},
+			want: `{"timestamp":"2024-01-02T03:04:05.006Z","level":"INFO","flag":"","message":"hello","uuid":"u-1",` +
+				`"transaction_id":"","trace_id":"","service_name":"","endpoint":"","method":"","execution_time":"",` +
+				`"hostname":"host","server_ip":"10.0.0.1","file":"main.go","line":12,"function":"main.run","body":"","fields":{}}`,
+		},
+		{
+			// encoding/json juga meng-escape <, > dan & agar aman di-embed di HTML
+			name: "escaping",
+			modify: func(e *logger.LogEntry) {
+				e.Message = "say \"hi\"\n<b>\tA&B\\"
+				e.Body = `{"a":1}`
+			},
+			want: `{"timestamp":"2024-01-02T03:04:05.006Z","level":"INFO","flag":"","message":"say \"hi\"\n\u003cb\u003e\tA\u0026B\\","uuid":"u-1",` +
+				`"transaction_id":"","trace_id":"","service_name":"","endpoint":"","method":"","execution_time":"",` +
+				`"hostname":"host","server_ip":"10.0.0.1","file":"main.go","line":12,"function":"main.run","body":"{\"a\":1}","fields":{}}`,
+		},
+		{
+			name: "mandatory fields, trace and pod",
+			modify: func(e *logger.LogEntry) {
+				e.Flag = logger.FlagStart
+				e.TransactionID = "txn-1"
+				e.TraceID = "trace-1"
+				e.SpanID = "span-1"
+				e.TraceFlags = "01"
+				e.ServiceName = "svc"
+				e.Endpoint = "/orders"
+				e.MethodType = "POST"
+				e.ExecutionTime = "1.5ms"
+				e.PodName = "pod-1"
+				e.Namespace = "prod"
+				e.NodeName = "node-1"
+			},
+			want: `{"timestamp":"2024-01-02T03:04:05.006Z","level":"INFO","flag":"START","message":"hello","uuid":"u-1",` +
+				`"transaction_id":"txn-1","trace_id":"trace-1","span_id":"span-1","trace_flags":"01","service_name":"svc",` +
+				`"endpoint":"/orders","method":"POST","execution_time":"1.5ms","hostname":"host","server_ip":"10.0.0.1",` +
+				`"pod_name":"pod-1","namespace":"prod","node_name":"node-1","file":"main.go","line":12,"function":"main.run","body":"","fields":{}}`,
+		},
+		{
+			// Key di object "fields" diurutkan oleh encoding/json; angka, bool dan Any tetap typed
+			name: "typed fields",
+			modify: func(e *logger.LogEntry) {
+				e.Fields = []logger.Field{
+					logger.String("user", "a b"),
+					logger.Int("attempt", 3),
+					logger.Bool("ok", false),
+					logger.Duration("took", 1500*time.Millisecond),
+					logger.Any("tags", []string{"x"}),
+				}
+			},
+			want: `{"timestamp":"2024-01-02T03:04:05.006Z","level":"INFO","flag":"","message":"hello","uuid":"u-1",` +
+				`"transaction_id":"","trace_id":"","service_name":"","endpoint":"","method":"","execution_time":"",` +
+				`"hostname":"host","server_ip":"10.0.0.1","file":"main.go","line":12,"function":"main.run","body":"",` +
+				`"fields":{"attempt":3,"ok":false,"tags":["x"],"took":"1.5s","user":"a b"}}`,
+		},
+		{
+			name: "non-UTC time keeps its offset",
+			modify: func(e *logger.LogEntry) {
+				e.Time = time.Date(2024, 1, 2, 10, 4, 5, 0, time.FixedZone("WIB", 7*60*60))
+			},
+			want: `{"timestamp":"2024-01-02T10:04:05.000+07:00","level":"INFO","flag":"","message":"hello","uuid":"u-1",` +
+				`"transaction_id":"","trace_id":"","service_name":"","endpoint":"","method":"","execution_time":"",` +
+				`"hostname":"host","server_ip":"10.0.0.1","file":"main.go","line":12,"function":"main.run","body":"","fields":{}}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			entry := goldenEntry()
+			tt.modify(&entry)
+			if got := logger.FormatJSON.Format(&entry); got != tt.want {
+				t.Errorf("FormatJSON mismatch\n got: %s\nwant: %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFormatLogfmtGolden(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(e *logger.LogEntry)
+		want   string
+	}{
+		{
+			name:   "minimal skips empty values",
+			modify: func(e *logger.LogEntry) {},
+			want:   `timestamp=2024-01-02T03:04:05.006Z level=INFO message=hello uuid=u-1 hostname=host server_ip=10.0.0.1 file=main.go line=12 function=main.run`,
+		},
+		{
+			name: "quoting",
+			modify: func(e *logger.LogEntry) {
+				e.Message = `say "hi"`
+				e.Body = "a=b"
+				e.Function = `back\slash`
+			},
+			want: `timestamp=2024-01-02T03:04:05.006Z level=INFO message="say \"hi\"" uuid=u-1 hostname=host server_ip=10.0.0.1 ` +
+				`file=main.go line=12 function="back\\slash" body="a=b"`,
+		},
+		{
+			name: "control characters",
+			modify: func(e *logger.LogEntry) {
+				e.Message = "line1\nline2\ttab"
+			},
+			want: `timestamp=2024-01-02T03:04:05.006Z level=INFO message="line1\nline2\ttab" uuid=u-1 hostname=host server_ip=10.0.0.1 ` +
+				`file=main.go line=12 function=main.run`,
+		},
+		{
+			name: "zero line is omitted",
+			modify: func(e *logger.LogEntry) {
+				e.Line = 0
+			},
+			want: `timestamp=2024-01-02T03:04:05.006Z level=INFO message=hello uuid=u-1 hostname=host server_ip=10.0.0.1 file=main.go function=main.run`,
+		},
+		{
+			// Field ditulis setelah key standar, dalam urutan aslinya
+			name: "fields keep insertion order",
+			modify: func(e *logger.LogEntry) {
+				e.TransactionID = "txn-1"
+				e.Flag = logger.FlagStop
+				e.Fields = []logger.Field{
+					logger.String("user", "a b"),
+					logger.Int("attempt", 3),
+					logger.Bool("ok", true),
+					logger.String("empty", ""),
+				}
+			},
+			want: `timestamp=2024-01-02T03:04:05.006Z level=INFO flag=STOP message=hello uuid=u-1 transaction_id=txn-1 ` +
+				`hostname=host server_ip=10.0.0.1 file=main.go line=12 function=main.run user="a b" attempt=3 ok=true`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			entry := goldenEntry()
+			tt.modify(&entry)
+			if got := logger.FormatLogfmt.Format(&entry); got != tt.want {
+				t.Errorf("FormatLogfmt mismatch\n got: %s\nwant: %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFormatTextGolden(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(e *logger.LogEntry)
+		want   string
+	}{
+		{
+			name:   "plain",
+			modify: func(e *logger.LogEntry) {},
+			want:   `[2024-01-02 03:04:05.006] [INFO] [u-1] [host@10.0.0.1] [main.go:12:main.run] hello`,
+		},
+		{
+			name: "trace, pod and fields",
+			modify: func(e *logger.LogEntry) {
+				e.TraceID = "trace-1"
+				e.SpanID = "span-1"
+				e.PodName = "pod-1"
+				e.Namespace = "prod"
+				e.NodeName = "node-1"
+				e.Fields = []logger.Field{logger.String("user", "a b"), logger.Int("n", 1)}
+			},
+			want: `[2024-01-02 03:04:05.006] [INFO] [u-1] [host@10.0.0.1 pod=prod/pod-1 node=node-1] [main.go:12:main.run] hello ` +
+				`trace_id=trace-1 span_id=span-1 user="a b" n=1`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			entry := goldenEntry()
+			tt.modify(&entry)
+			if got := logger.FormatText.Format(&entry); got != tt.want {
+				t.Errorf("FormatText mismatch\n got: %s\nwant: %s", got, tt.want)
+			}
+		})
+	}
+}
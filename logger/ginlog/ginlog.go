@@ -0,0 +1,146 @@
+// Package ginlog provides the request logging middleware of package logger for Gin.
+package ginlog
+
+import (
+	"context"
+	"net/http"
+	"runtime/debug"
+	"strings"
+
+	"github.com/funxdofficial/golang-module-syslog/logger"
+	"github.com/gin-gonic/gin"
+)
+
+// RequestInfo implements logger.HTTPRequestInfo for Gin
+type RequestInfo struct {
+	c    *gin.Context
+	body string // Request body yang di-capture oleh middleware
+}
+
+// NewRequestInfo wraps a Gin context as logger.HTTPRequestInfo
+func NewRequestInfo(c *gin.Context) *RequestInfo {
+	return &RequestInfo{c: c}
+}
+
+func (r *RequestInfo) Method() string {
+	return r.c.Request.Method
+}
+
+func (r *RequestInfo) Path() string {
+	return r.c.Request.URL.Path
+}
+
+func (r *RequestInfo) Body() string {
+	// Body di-capture oleh Middleware (lihat MiddlewareConfig.CaptureRequestBody)
+	return r.body
+}
+
+func (r *RequestInfo) Header(key string) string {
+	return r.c.GetHeader(key)
+}
+
+func (r *RequestInfo) Context() context.Context {
+	return r.c.Request.Context()
+}
+
+// Middleware logs START and STOP for every request, seperti logger.StandardHTTPMiddleware.
+// Endpoint berisi route template (c.FullPath(), misalnya "/users/:id") dan path asli ditulis
+// sebagai field "path". Entry STOP berisi status, ukuran response dan error dari c.Errors.
+func Middleware(l *logger.Logger, config logger.MiddlewareConfig) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		// Skip paths jika ada
+		if config.ShouldSkip(c.Request.URL.Path) {
+			c.Next()
+			return
+		}
+
+		// Route template, kosong jika tidak ada route yang cocok (404)
+		route := c.FullPath()
+		if route != "" && route != c.Request.URL.Path {
+			c.Request = c.Request.WithContext(logger.WithFields(c.Request.Context(), logger.String("path", c.Request.URL.Path)))
+		}
+
+		// Start logging
+		startConfig := logger.StartConfig{
+			ServiceName: config.ServiceName,
+			Endpoint:    route,
+			Propagation: config.Propagation,
+		}
+		reqInfo := &RequestInfo{c: c, body: config.ReadRequestBody(c.Request)}
+		ctx := l.StartFromHTTPRequestInfo(reqInfo, startConfig)
+
+		// Echo correlation IDs agar client / gateway bisa mencocokkan log
+		if !config.SkipResponseHeaders {
+			logger.SetCorrelationHeaders(c.Writer.Header(), logger.CorrelationFromContext(ctx))
+		}
+
+		// Update context dan wrap writer untuk capture response body
+		c.Request = c.Request.WithContext(ctx)
+		writer := &bodyWriter{ResponseWriter: c.Writer, body: config.NewResponseCapture(c.Request.URL.Path)}
+		c.Writer = writer
+
+		// Recover panic dari handler dan tulis ERROR STOP
+		if config.RecoverPanic {
+			defer func() {
+				if rec := recover(); rec != nil {
+					stack := debug.Stack()
+					if rec != http.ErrAbortHandler {
+						c.Abort()
+						if !c.Writer.Written() {
+							config.WritePanicResponse(c.Writer)
+						}
+					}
+					fields := []logger.Field{
+						logger.Int("status", c.Writer.Status()),
+						logger.Int("bytes", max(c.Writer.Size(), 0)),
+					}
+					l.StopPanic(logger.WithFields(ctx, fields...), rec, stack, writer.body.String())
+					if config.AccessLogger != nil {
+						config.AccessLogger.LogAccess(ctx, logger.NewAccessLogInfo(c.Request, c.Writer.Status(), int64(max(c.Writer.Size(), 0))))
+					}
+					if config.ShouldRepanic(rec) {
+						panic(rec)
+					}
+				}
+			}()
+		}
+
+		// Process request
+		c.Next()
+
+		// Stop logging
+		status := c.Writer.Status()
+		level := logger.LevelForStatus(status)
+		fields := []logger.Field{
+			logger.Int("status", status),
+			logger.Int("bytes", max(c.Writer.Size(), 0)),
+		}
+		if len(c.Errors) > 0 {
+			level = "ERROR"
+			fields = append(fields, logger.String("errors", strings.Join(c.Errors.Errors(), "; ")))
+		}
+
+		l.Stop(logger.WithFields(ctx, fields...), level, "Request completed", writer.body.String())
+		if config.AccessLogger != nil {
+			config.AccessLogger.LogAccess(ctx, logger.NewAccessLogInfo(c.Request, status, int64(max(c.Writer.Size(), 0))))
+		}
+	}
+}
+
+// bodyWriter wraps gin.ResponseWriter untuk capture response body
+type bodyWriter struct {
+	gin.ResponseWriter
+	body *logger.BodyCapture
+}
+
+func (w *bodyWriter) Write(b []byte) (int, error) {
+	n, err := w.ResponseWriter.Write(b)
+	w.body.Capture(w.Header(), b[:n])
+	return n, err
+}
+
+func (w *bodyWriter) WriteString(s string) (int, error) {
+	n, err := w.ResponseWriter.WriteString(s)
+	w.body.Capture(w.Header(), []byte(s[:n]))
+	return n, err
+}
@@ -0,0 +1,131 @@
+package ginlog_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/funxdofficial/golang-module-syslog/logger"
+	"github.com/funxdofficial/golang-module-syslog/logger/ginlog"
+	"github.com/funxdofficial/golang-module-syslog/logger/logtest"
+	"github.com/gin-gonic/gin"
+)
+
+func newRouter(t *testing.T, config logger.MiddlewareConfig) (*gin.Engine, *logtest.Observer) {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+	appLogger, logs := logtest.New(t)
+
+	router := gin.New()
+	router.Use(ginlog.Middleware(appLogger, config))
+	router.GET("/users/:id", func(c *gin.Context) {
+		c.JSON(http.StatusOK, gin.H{"id": c.Param("id")})
+	})
+	router.POST("/users", func(c *gin.Context) {
+		c.Error(errors.New("validation failed"))
+		c.String(http.StatusBadRequest, "bad request")
+	})
+	router.GET("/panic", func(c *gin.Context) {
+		panic("boom")
+	})
+	return router, logs
+}
+
+func TestMiddlewareStartStop(t *testing.T) {
+	router, logs := newRouter(t, logger.MiddlewareConfig{ServiceName: "user-service"})
+
+	req := httptest.NewRequest(http.MethodGet, "/users/42", nil)
+	req.Header.Set("X-Request-ID", "req-123")
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, want 200", rec.Code)
+	}
+	if got := rec.Header().Get("X-Request-ID"); got != "req-123" {
+		t.Errorf("response X-Request-ID = %q, want req-123", got)
+	}
+
+	entries := logs.AssertTransaction(t, "req-123")
+	for _, e := range entries {
+		if e.Endpoint != "/users/:id" || e.MethodType != http.MethodGet || e.ServiceName != "user-service" {
+			t.Errorf("%s entry: endpoint=%s method=%s service=%s", e.Flag, e.Endpoint, e.MethodType, e.ServiceName)
+		}
+		if path, _ := e.Field("path"); path != "/users/42" {
+			t.Errorf("%s entry: field path = %v, want /users/42", e.Flag, path)
+		}
+	}
+
+	stop := entries.Flag(logger.FlagStop)[0]
+	if stop.Level() != logger.LevelSuccess {
+		t.Errorf("STOP level = %s, want SUCCESS", stop.Level())
+	}
+	if status, _ := stop.Field("status"); status != int64(http.StatusOK) {
+		t.Errorf("STOP status = %v, want 200", status)
+	}
+	if bytes, _ := stop.Field("bytes"); bytes != int64(rec.Body.Len()) {
+		t.Errorf("STOP bytes = %v, want %d", bytes, rec.Body.Len())
+	}
+}
+
+func TestMiddlewareHandlerErrors(t *testing.T) {
+	router, logs := newRouter(t, logger.MiddlewareConfig{})
+
+	req := httptest.NewRequest(http.MethodPost, "/users", nil)
+	req.Header.Set("X-Request-ID", "req-err")
+	router.ServeHTTP(httptest.NewRecorder(), req)
+
+	stop := logs.AssertTransaction(t, "req-err").Flag(logger.FlagStop)[0]
+	if stop.Level() != logger.LevelError {
+		t.Errorf("STOP level = %s, want ERROR", stop.Level())
+	}
+	if status, _ := stop.Field("status"); status != int64(http.StatusBadRequest) {
+		t.Errorf("STOP status = %v, want 400", status)
+	}
+	if errs, _ := stop.Field("errors"); errs != "validation failed" {
+		t.Errorf("STOP errors = %v, want validation failed", errs)
+	}
+}
+
+func TestMiddlewareNotFound(t *testing.T) {
+	router, logs := newRouter(t, logger.MiddlewareConfig{})
+
+	req := httptest.NewRequest(http.MethodGet, "/missing", nil)
+	req.Header.Set("X-Request-ID", "req-404")
+	router.ServeHTTP(httptest.NewRecorder(), req)
+
+	stop := logs.AssertTransaction(t, "req-404").Flag(logger.FlagStop)[0]
+	if stop.Endpoint != "/missing" {
+		t.Errorf("STOP endpoint = %s, want the request path when no route matches", stop.Endpoint)
+	}
+	if status, _ := stop.Field("status"); status != int64(http.StatusNotFound) {
+		t.Errorf("STOP status = %v, want 404", status)
+	}
+}
+
+func TestMiddlewareRecoverPanic(t *testing.T) {
+	router, logs := newRouter(t, logger.MiddlewareConfig{RecoverPanic: true})
+
+	req := httptest.NewRequest(http.MethodGet, "/panic", nil)
+	req.Header.Set("X-Request-ID", "req-panic")
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusInternalServerError {
+		t.Errorf("status = %d, want 500", rec.Code)
+	}
+	stop := logs.AssertTransaction(t, "req-panic").Flag(logger.FlagStop)[0]
+	if stop.Level() != logger.LevelError {
+		t.Errorf("STOP level = %s, want ERROR", stop.Level())
+	}
+}
+
+func TestMiddlewareSkipPaths(t *testing.T) {
+	router, logs := newRouter(t, logger.MiddlewareConfig{SkipPaths: []string{"/users/1"}})
+
+	router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/users/1", nil))
+	if logs.Len() != 0 {
+		t.Errorf("got %d entries for a skipped path, want 0", logs.Len())
+	}
+}
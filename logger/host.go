@@ -0,0 +1,188 @@
+package logger
+
+import (
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// IPVersion selects the address family of the server IP
+type IPVersion string
+
+const (
+	IPAny IPVersion = ""     // IPv4 jika ada, jika tidak IPv6 (default)
+	IPv4  IPVersion = "ipv4" // Hanya IPv4
+	IPv6  IPVersion = "ipv6" // Hanya IPv6
+)
+
+// DefaultPodInfoDir is the default mount path of the Kubernetes Downward API volume
+const DefaultPodInfoDir = "/etc/podinfo"
+
+// serviceAccountNamespaceFile contains the namespace of the pod when a service account is mounted
+const serviceAccountNamespaceFile = "/var/run/secrets/kubernetes.io/serviceaccount/namespace"
+
+// HostIdentity identifies the host (dan pod Kubernetes) yang menulis log, dipasang di setiap entry
+type HostIdentity struct {
+	Hostname  string
+	IP        string
+	PodName   string
+	Namespace string
+	NodeName  string
+}
+
+// HostConfig untuk deteksi identitas host. Nilai yang diisi dipakai apa adanya (override),
+// nilai kosong dideteksi otomatis.
+type HostConfig struct {
+	Hostname string // Default: os.Hostname()
+	IP       string // Default: alamat pertama dari interface yang aktif (tanpa dial keluar)
+
+	IPVersion IPVersion // Address family untuk deteksi IP (default: IPv4, fallback IPv6)
+	Interface string    // Nama interface untuk deteksi IP, misalnya "eth0" (default: semua interface non-loopback)
+
+	// Identitas Kubernetes. Default dibaca dari env POD_NAME, POD_NAMESPACE dan NODE_NAME,
+	// lalu dari file podname, namespace dan nodename di PodInfoDir (Downward API volume).
+	PodName    string
+	Namespace  string
+	NodeName   string
+	PodInfoDir string // Default: DefaultPodInfoDir
+}
+
+// apply sets the host fields of entry. ServerIP dari entry dipertahankan jika sudah diisi.
+func (h HostIdentity) apply(entry *LogEntry) {
+	entry.Hostname = h.Hostname
+	entry.PodName = h.PodName
+	entry.Namespace = h.Namespace
+	entry.NodeName = h.NodeName
+	if entry.ServerIP == "" {
+		entry.ServerIP = h.IP
+	}
+}
+
+// Host returns the host identity attached to every entry of this logger
+func (l *Logger) Host() HostIdentity {
+	return l.host
+}
+
+// DetectHostIdentity resolves the host identity from config, environment and network interfaces
+func DetectHostIdentity(config HostConfig) (HostIdentity, error) {
+	switch config.IPVersion {
+	case IPAny, IPv4, IPv6:
+	default:
+		return HostIdentity{}, fmt.Errorf("unsupported IP version '%s'", config.IPVersion)
+	}
+
+	identity := HostIdentity{
+		Hostname:  config.Hostname,
+		IP:        config.IP,
+		PodName:   config.PodName,
+		Namespace: config.Namespace,
+		NodeName:  config.NodeName,
+	}
+	if identity.Hostname == "" {
+		identity.Hostname = getHostname()
+	}
+	if identity.IP == "" {
+		identity.IP = getLocalIP(config.IPVersion, config.Interface)
+	}
+
+	podInfoDir := config.PodInfoDir
+	if podInfoDir == "" {
+		podInfoDir = DefaultPodInfoDir
+	}
+	if identity.PodName == "" {
+		identity.PodName = podInfo("POD_NAME", filepath.Join(podInfoDir, "podname"))
+	}
+	if identity.Namespace == "" {
+		identity.Namespace = podInfo("POD_NAMESPACE", filepath.Join(podInfoDir, "namespace"), serviceAccountNamespaceFile)
+	}
+	if identity.NodeName == "" {
+		identity.NodeName = podInfo("NODE_NAME", filepath.Join(podInfoDir, "nodename"))
+	}
+	return identity, nil
+}
+
+// podInfo returns the value of the env variable, atau isi file pertama yang ada
+func podInfo(env string, files ...string) string {
+	if value := strings.TrimSpace(os.Getenv(env)); value != "" {
+		return value
+	}
+	for _, file := range files {
+		if data, err := os.ReadFile(file); err == nil {
+			if value := strings.TrimSpace(string(data)); value != "" {
+				return value
+			}
+		}
+	}
+	return ""
+}
+
+// getLocalIP returns the first usable address of the network interfaces,
+// tanpa membuka koneksi (tidak butuh akses jaringan keluar)
+func getLocalIP(version IPVersion, name string) string {
+	interfaces, err := net.Interfaces()
+	if err != nil {
+		return "unknown"
+	}
+
+	var v4, v6 string
+	for _, iface := range interfaces {
+		if name != "" && iface.Name != name {
+			continue
+		}
+		if iface.Flags&net.FlagUp == 0 || (name == "" && iface.Flags&net.FlagLoopback != 0) {
+			continue
+		}
+		addrs, err := iface.Addrs()
+		if err != nil {
+			continue
+		}
+		for _, addr := range addrs {
+			ipNet, ok := addr.(*net.IPNet)
+			if !ok || !usableIP(ipNet.IP, name != "") {
+				continue
+			}
+			if ip4 := ipNet.IP.To4(); ip4 != nil {
+				if v4 == "" {
+					v4 = ip4.String()
+				}
+			} else if v6 == "" {
+				v6 = ipNet.IP.String()
+			}
+		}
+	}
+
+	switch {
+	case version == IPv4 && v4 != "":
+		return v4
+	case version == IPv6 && v6 != "":
+		return v6
+	case version == IPAny && v4 != "":
+		return v4
+	case version == IPAny && v6 != "":
+		return v6
+	}
+	return "unknown"
+}
+
+// usableIP reports whether ip identifies the host; link-local dan loopback hanya dipakai
+// jika interface dipilih secara eksplisit
+func usableIP(ip net.IP, explicit bool) bool {
+	if ip.IsUnspecified() || ip.IsMulticast() {
+		return false
+	}
+	if explicit {
+		return true
+	}
+	return !ip.IsLoopback() && !ip.IsLinkLocalUnicast()
+}
+
+// getHostname returns the hostname of the system
+func getHostname() string {
+	hostname, err := os.Hostname()
+	if err != nil {
+		return "unknown"
+	}
+	return hostname
+}
@@ -0,0 +1,189 @@
+package logger_test
+
+import (
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/funxdofficial/golang-module-syslog/logger"
+	"github.com/funxdofficial/golang-module-syslog/logger/logtest"
+)
+
+// clearPodEnv unsets the Kubernetes env variables for the duration of the test
+func clearPodEnv(t *testing.T) {
+	t.Helper()
+	for _, env := range []string{"POD_NAME", "POD_NAMESPACE", "NODE_NAME"} {
+		t.Setenv(env, "")
+	}
+}
+
+// writePodInfo writes Downward API files to a temp dir and returns the dir
+func writePodInfo(t *testing.T, files map[string]string) string {
+	t.Helper()
+	dir := t.TempDir()
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return dir
+}
+
+func TestDetectHostIdentityKubernetes(t *testing.T) {
+	tests := []struct {
+		name   string
+		env    map[string]string
+		files  map[string]string
+		config logger.HostConfig
+		want   logger.HostIdentity
+	}{
+		{
+			name: "env",
+			env:  map[string]string{"POD_NAME": "api-7d9f", "POD_NAMESPACE": "prod", "NODE_NAME": "node-a"},
+			want: logger.HostIdentity{PodName: "api-7d9f", Namespace: "prod", NodeName: "node-a"},
+		},
+		{
+			name:  "downward API files, trimmed",
+			files: map[string]string{"podname": "api-7d9f\n", "namespace": " prod \n", "nodename": "node-a"},
+			want:  logger.HostIdentity{PodName: "api-7d9f", Namespace: "prod", NodeName: "node-a"},
+		},
+		{
+			name:  "env wins over files",
+			env:   map[string]string{"POD_NAME": "from-env"},
+			files: map[string]string{"podname": "from-file", "namespace": "prod"},
+			want:  logger.HostIdentity{PodName: "from-env", Namespace: "prod"},
+		},
+		{
+			name:  "blank env falls back to files",
+			env:   map[string]string{"POD_NAME": "  "},
+			files: map[string]string{"podname": "from-file", "namespace": "prod"},
+			want:  logger.HostIdentity{PodName: "from-file", Namespace: "prod"},
+		},
+		{
+			name:   "config overrides env and files",
+			env:    map[string]string{"POD_NAME": "from-env", "NODE_NAME": "node-env"},
+			files:  map[string]string{"namespace": "from-file"},
+			config: logger.HostConfig{PodName: "from-config", Namespace: "ns-config"},
+			want:   logger.HostIdentity{PodName: "from-config", Namespace: "ns-config", NodeName: "node-env"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clearPodEnv(t)
+			for k, v := range tt.env {
+				t.Setenv(k, v)
+			}
+			config := tt.config
+			config.Hostname, config.IP = "test-host", "10.0.0.1"
+			config.PodInfoDir = writePodInfo(t, tt.files)
+
+			got, err := logger.DetectHostIdentity(config)
+			if err != nil {
+				t.Fatal(err)
+			}
+			want := tt.want
+			want.Hostname, want.IP = "test-host", "10.0.0.1"
+			if got != want {
+				t.Errorf("DetectHostIdentity = %+v, want %+v", got, want)
+			}
+		})
+	}
+}
+
+func TestDetectHostIdentityOutsideKubernetes(t *testing.T) {
+	if _, err := os.Stat("/var/run/secrets/kubernetes.io/serviceaccount/namespace"); err == nil {
+		t.Skip("running inside a Kubernetes pod")
+	}
+	clearPodEnv(t)
+
+	got, err := logger.DetectHostIdentity(logger.HostConfig{PodInfoDir: t.TempDir()})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.PodName != "" || got.Namespace != "" || got.NodeName != "" {
+		t.Errorf("pod fields = %+v, want empty outside Kubernetes", got)
+	}
+	if hostname, _ := os.Hostname(); got.Hostname != hostname {
+		t.Errorf("Hostname = %q, want %q", got.Hostname, hostname)
+	}
+	if got.IP == "" {
+		t.Error("IP is empty, want a detected address or \"unknown\"")
+	}
+}
+
+// loopbackInterface returns the name of the loopback interface (lo, lo0, ...)
+func loopbackInterface(t *testing.T) string {
+	t.Helper()
+	interfaces, err := net.Interfaces()
+	if err != nil {
+		t.Skip(err)
+	}
+	for _, iface := range interfaces {
+		if iface.Flags&net.FlagLoopback != 0 && iface.Flags&net.FlagUp != 0 {
+			return iface.Name
+		}
+	}
+	t.Skip("no loopback interface")
+	return ""
+}
+
+func TestDetectHostIdentityInterface(t *testing.T) {
+	lo := loopbackInterface(t)
+	tests := []struct {
+		name    string
+		version logger.IPVersion
+		want    string // "" = error
+	}{
+		{"ipv4", logger.IPv4, "127.0.0.1"},
+		{"any", logger.IPAny, "127.0.0.1"},
+		{"invalid version", "ipv5", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := logger.DetectHostIdentity(logger.HostConfig{Interface: lo, IPVersion: tt.version, PodInfoDir: t.TempDir()})
+			if tt.want == "" {
+				if err == nil {
+					t.Errorf("IPVersion %q accepted, want an error", tt.version)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got.IP != tt.want {
+				t.Errorf("IP = %q, want %q", got.IP, tt.want)
+			}
+		})
+	}
+
+	// Interface yang tidak ada menghasilkan "unknown"
+	got, err := logger.DetectHostIdentity(logger.HostConfig{Interface: "does-not-exist0", PodInfoDir: t.TempDir()})
+	if err != nil || got.IP != "unknown" {
+		t.Errorf("IP for a missing interface = %q, %v; want unknown", got.IP, err)
+	}
+}
+
+func TestLoggerHostOnEntries(t *testing.T) {
+	clearPodEnv(t)
+	t.Setenv("NODE_NAME", "node-a")
+	appLogger, logs := logtest.NewWithConfig(t, &logger.LoggerConfig{
+		MinLevel: logger.LevelInfo,
+		Host: logger.HostConfig{
+			Hostname:   "test-host",
+			IP:         "10.0.0.1",
+			PodInfoDir: writePodInfo(t, map[string]string{"podname": "api-7d9f", "namespace": "prod"}),
+		},
+	})
+
+	want := logger.HostIdentity{Hostname: "test-host", IP: "10.0.0.1", PodName: "api-7d9f", Namespace: "prod", NodeName: "node-a"}
+	if got := appLogger.Host(); got != want {
+		t.Errorf("Host = %+v, want %+v", got, want)
+	}
+
+	appLogger.Info("hello")
+	e := logs.AssertLogged(t, logger.LevelInfo, "hello")
+	if e.Hostname != "test-host" || e.ServerIP != "10.0.0.1" || e.PodName != "api-7d9f" || e.Namespace != "prod" || e.NodeName != "node-a" {
+		t.Errorf("entry host fields = %s@%s pod=%s/%s node=%s", e.Hostname, e.ServerIP, e.Namespace, e.PodName, e.NodeName)
+	}
+}
@@ -0,0 +1,158 @@
+package logger_test
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/funxdofficial/golang-module-syslog/logger"
+	"github.com/funxdofficial/golang-module-syslog/logger/logtest"
+)
+
+func TestMinLevelDropsFilteredCalls(t *testing.T) {
+	ids := logtest.NewSequenceIDs()
+	appLogger, logs := logtest.NewWithConfig(t, &logger.LoggerConfig{
+		MinLevel:    logger.LevelWarning,
+		IDGenerator: ids,
+	})
+
+	appLogger.Trace("trace message")
+	appLogger.Debug("debug message")
+	appLogger.Info("info message")
+	appLogger.Success("success message")
+	appLogger.Warning("warning message")
+	appLogger.Error("error message")
+
+	if got := logs.All().Messages(); strings.Join(got, ",") != "warning message,error message" {
+		t.Fatalf("logged messages = %q, want only WARNING and ERROR", got)
+	}
+	// Log yang di-filter tidak generate UUID: entry pertama yang lolos memakai ID nomor 1
+	if got := logs.All()[0].UUID; got != "00000000-0000-7000-8000-000000000001" {
+		t.Errorf("UUID of first logged entry = %s, filtered calls must not consume IDs", got)
+	}
+	if got := appLogger.Dropped(); got != 0 {
+		t.Errorf("Dropped = %d, filtered entries must not count as dropped", got)
+	}
+}
+
+func TestSetLevelAtRuntime(t *testing.T) {
+	appLogger, logs := logtest.NewWithConfig(t, &logger.LoggerConfig{MinLevel: logger.LevelInfo})
+	if got := appLogger.Level(); got != logger.LevelInfo {
+		t.Fatalf("Level = %s, want INFO", got)
+	}
+
+	appLogger.Debug("before SetLevel")
+	appLogger.SetLevel(logger.LevelDebug)
+	appLogger.Debug("after SetLevel")
+	appLogger.SetLevel(logger.LevelError)
+	appLogger.Warning("after raising level")
+
+	logs.AssertNotLogged(t, logger.LevelDebug, "before SetLevel")
+	logs.AssertLogged(t, logger.LevelDebug, "after SetLevel")
+	logs.AssertNotLogged(t, logger.LevelWarning, "after raising level")
+
+	// Level 0 berarti default (INFO)
+	appLogger.SetLevel(0)
+	if got := appLogger.Level(); got != logger.LevelInfo {
+		t.Errorf("Level after SetLevel(0) = %s, want INFO", got)
+	}
+
+	// Logger turunan dari With berbagi level yang sama
+	child := appLogger.With("component", "worker")
+	appLogger.SetLevel(logger.LevelTrace)
+	child.Trace("child trace")
+	logs.AssertLogged(t, logger.LevelTrace, "child trace")
+}
+
+func TestSetLevelConcurrent(t *testing.T) {
+	appLogger, _ := logtest.NewWithConfig(t, &logger.LoggerConfig{MinLevel: logger.LevelInfo})
+
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				appLogger.SetLevel(logger.LevelDebug)
+				appLogger.SetLevel(logger.LevelInfo)
+			}
+		}()
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				appLogger.Debug("debug %d", j)
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+func TestPerSinkMinLevel(t *testing.T) {
+	console := &logtest.Observer{}
+	file := &logtest.Observer{}
+	appLogger, all := logtest.NewWithConfig(t, &logger.LoggerConfig{
+		MinLevel: logger.LevelDebug,
+		Sinks: []logger.SinkConfig{
+			{Sink: console, MinLevel: logger.LevelInfo},
+			{Sink: file, MinLevel: logger.LevelWarning},
+		},
+	})
+
+	appLogger.Trace("trace")
+	appLogger.Debug("debug")
+	appLogger.Info("info")
+	appLogger.Warning("warning")
+	appLogger.Error("error")
+
+	tests := []struct {
+		name     string
+		observer *logtest.Observer
+		want     string
+	}{
+		{"console=INFO", console, "info,warning,error"},
+		{"file=WARNING", file, "warning,error"},
+		{"observer without MinLevel follows global level", all, "debug,info,warning,error"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := strings.Join(tt.observer.All().Messages(), ","); got != tt.want {
+				t.Errorf("messages = %s, want %s", got, tt.want)
+			}
+		})
+	}
+
+	// Sink MinLevel tidak bisa menurunkan level global
+	appLogger.SetLevel(logger.LevelError)
+	appLogger.Warning("after SetLevel")
+	if got := len(file.All().Message("after SetLevel")); got != 0 {
+		t.Errorf("file sink got %d entries below the global level", got)
+	}
+}
+
+func TestFileMinLevel(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "app.log")
+	appLogger, logs := logtest.NewWithConfig(t, &logger.LoggerConfig{
+		Type:         logger.LogTypeFile,
+		LogFile:      path,
+		FileFormat:   logger.FormatLogfmt,
+		MinLevel:     logger.LevelInfo,
+		FileMinLevel: logger.LevelWarning,
+	})
+
+	appLogger.Info("info only in observer")
+	appLogger.Warning("warning in file")
+	logs.AssertCount(t, logger.LevelInfo, 1)
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(data), "info only in observer") {
+		t.Errorf("file contains an INFO entry below FileMinLevel:\n%s", data)
+	}
+	if !strings.Contains(string(data), `message="warning in file"`) {
+		t.Errorf("file is missing the WARNING entry:\n%s", data)
+	}
+}
@@ -3,28 +3,36 @@ package logger
 import (
 	"context"
 	"fmt"
-	"io"
 	"log"
-	"net"
 	"net/http"
 	"os"
 	"path/filepath"
+	"reflect"
 	"runtime"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/google/uuid"
 )
 
-// LogLevel represents the severity level of a log entry
+// LogLevel represents the severity level of a log entry.
+// Semakin kecil nilainya semakin severe; 0 berarti belum di-set.
+//
+// Breaking change: nilainya berbeda dari versi sebelumnya (LevelError dulu 0, LevelInfo 3).
+// Simpan level sebagai konstanta atau nama (ParseLevel), bukan angka.
 type LogLevel int
 
 const (
-	LevelError LogLevel = iota
+	LevelFatal LogLevel = iota + 1
+	LevelPanic
+	LevelError
 	LevelWarning
 	LevelSuccess
 	LevelInfo
+	LevelDebug
+	LevelTrace
 )
 
 // ContextKey is a type for context keys
@@ -66,9 +74,23 @@ type LogEntry struct {
 	ExecutionTime string
 	ServerIP      string
 	TraceID       string
+	SpanID        string // Span aktif (OpenTelemetry atau WithCorrelation), kosong jika tidak ada
+	TraceFlags    string // W3C trace flags, "01" = sampled
 	Body          string
 	Flag          LogFlag
 	Message       string
+	Fields        []Field // Structured fields dari With dan WithFields
+
+	// Context tambahan, diisi otomatis oleh worker
+	Time      time.Time // Waktu log dalam bentuk time.Time (Timestamp adalah versi string-nya)
+	UUID      string
+	Hostname  string
+	PodName   string // Kosong jika tidak berjalan di Kubernetes
+	Namespace string
+	NodeName  string
+	File      string
+	Line      int
+	Function  string
 }
 
 // StartConfig represents configuration for starting a log entry
@@ -81,6 +103,10 @@ type StartConfig struct {
 	Body          string
 	Message       string
 	Level         string
+
+	// Propagation adalah urutan prioritas header korelasi untuk StartFromRequest dan
+	// StartFromHTTPRequestInfo (default: DefaultPropagation)
+	Propagation []PropagationSource
 }
 
 // LogType represents the type of logging output
@@ -90,6 +116,7 @@ const (
 	LogTypeConsole LogType = "console" // Hanya console (dengan warna)
 	LogTypeFile    LogType = "file"    // Hanya file (tanpa warna, plain text)
 	LogTypeAll     LogType = "all"     // Console + File (console dengan warna, file tanpa warna)
+	LogTypeSyslog  LogType = "syslog"  // Hanya syslog (RFC 5424 / RFC 3164) via UDP, TCP atau unix socket
 )
 
 // LoggerConfig represents configuration for creating a logger instance
@@ -97,33 +124,131 @@ type LoggerConfig struct {
 	LogFile    string  // Path to log file (required jika Type = "file" atau "all")
 	Type       LogType // Type of logging: "console", "file", atau "all"
 	BufferSize int     // Buffer size untuk async logging channel (default: 1000)
+
+	// Output format: "text" (default), "json" atau "logfmt"
+	Format        LogFormat // Format untuk semua output
+	ConsoleFormat LogFormat // Override format untuk console (default: Format)
+	FileFormat    LogFormat // Override format untuk file (default: Format)
+
+	// Rotasi file log (opsional, dipakai jika Type = "file" atau "all")
+	MaxSizeMB      int            // Rotate jika ukuran file melebihi N MB (0 = tanpa batas)
+	RotateInterval RotateInterval // Rotate terjadwal: "hourly" atau "daily" (default: tidak terjadwal)
+	MaxBackups     int            // Jumlah file hasil rotasi yang disimpan (0 = simpan semua)
+	MaxAgeDays     int            // Hapus file hasil rotasi yang lebih tua dari N hari (0 = tidak dihapus)
+	Compress       bool           // Gzip file hasil rotasi
+
+	// Minimum level: log dengan level di bawah ini tidak ditulis (default: LevelInfo, DEBUG dan TRACE tidak ditulis)
+	MinLevel        LogLevel // Minimum level global, bisa diubah saat runtime dengan SetLevel
+	ConsoleMinLevel LogLevel // Minimum level untuk console (default: semua yang lolos MinLevel)
+	FileMinLevel    LogLevel // Minimum level untuk file (default: semua yang lolos MinLevel)
+	SyslogMinLevel  LogLevel // Minimum level untuk syslog (default: semua yang lolos MinLevel)
+
+	// Backpressure saat channel penuh
+	OverflowPolicy         OverflowPolicy // Default: OverflowDropNewest
+	OverflowTimeout        time.Duration  // Batas waktu menunggu untuk OverflowBlockTimeout (default: 100ms)
+	OverflowKeepLevel      LogLevel       // Level yang tidak boleh di-drop untuk OverflowDropBelowLevel (default: LevelError)
+	OverflowReportInterval time.Duration  // Interval summary jumlah log yang di-drop (default: 10s, negatif = nonaktif)
+
+	// Sampling dan rate limiting entry (nil = semua entry ditulis)
+	Sampling *SamplingConfig
+
+	// Syslog output (dipakai jika Type = "syslog"); untuk syslog bersama console atau file
+	// gunakan NewSyslogSink di Sinks
+	SyslogNetwork  string         // "udp", "tcp", "unix", "unixgram" atau "" untuk local syslog (/dev/log)
+	SyslogAddress  string         // Address collector (host:port) atau path unix socket
+	SyslogFacility SyslogFacility // Facility syslog (default: FacilityUser)
+	SyslogAppName  string         // APP-NAME / TAG (default: nama binary)
+	SyslogFormat   SyslogFormat   // SyslogRFC5424 (default) atau SyslogRFC3164
+
+	// Output tambahan (Kafka, HTTP, test capture, dll), masing-masing dengan level filter sendiri.
+	// Jika Type dan LogFile kosong, hanya sink ini yang dipakai (tanpa console default).
+	Sinks []SinkConfig
+
+	// ReopenOnSIGHUP membuka ulang LogFile (dan file sink lain) saat SIGHUP diterima,
+	// untuk logrotate eksternal dengan mode create. Lihat juga Logger.Reopen.
+	ReopenOnSIGHUP bool
+
+	// Redactor me-mask data sensitif di message, body dan fields sebelum di-format ke semua sink
+	// (nil = tanpa redaction), lihat NewRedactor
+	Redactor *Redactor
+
+	// Mode penulisan: ModeAsync (default, lewat worker goroutine) atau ModeSync (ditulis sebelum
+	// method log kembali). Timestamp selalu diambil saat method log dipanggil.
+	Mode LogMode
+
+	// Clock dan IDGenerator untuk timestamp, durasi dan ID (default: waktu sistem dan UUID v7 / crypto/rand).
+	// Implementasi deterministik membuat output byte-identical untuk golden-file test dan replay.
+	Clock       Clock
+	IDGenerator IDGenerator
+
+	// Host mengatur identitas host (hostname, IP, pod, namespace, node) yang dipasang di setiap
+	// entry. Kosong = dideteksi otomatis, lihat HostConfig.
+	Host HostConfig
+
+	// SpanContext membaca span aktif dari ctx di setiap *Ctx call dan LogWithMandatoryFields,
+	// misalnya otellog.SpanContext untuk OpenTelemetry (nil = hanya dari WithCorrelation)
+	SpanContext SpanContextFunc
 }
 
 // logMessage represents a log message to be written asynchronously
 type logMessage struct {
-	level     string
-	uuid      string
-	message   string
-	args      []interface{}
-	entry     *LogEntry // For mandatory fields logging
-	formatted string    // Pre-formatted message (optional)
+	level   string
+	uuid    string
+	message string
+	args    []interface{}
+	fields  []Field   // Structured fields dari With dan WithFields
+	entry   *LogEntry // For mandatory fields logging
+	time    time.Time // Waktu pemanggilan method log (bukan waktu worker menulis)
+	// Trace context dari ctx (hanya untuk *Ctx calls)
+	traceID    string
+	spanID     string
+	traceFlags string
 	// Caller info (captured at log call time, not worker time)
 	file     string
 	line     int
 	function string
+	// Flush marker, ditutup oleh worker saat semua message sebelumnya sudah ditulis
+	flushed chan struct{}
+	sync    bool  // Fsync sink setelah flush (Sync)
+	reopen  bool  // Buka ulang file sink setelah flush (Reopen)
+	err     error // Hasil fsync, dibaca setelah flushed ditutup
 }
 
-// Logger is the main logging structure
+// Logger is the main logging structure.
+// Child logger dari With berbagi loggerCore (worker, channel dan sink) dengan parent-nya.
 type Logger struct {
-	errorLog      *log.Logger
-	warningLog    *log.Logger
-	successLog    *log.Logger
-	infoLog       *log.Logger
-	file          *os.File
-	useFile       bool
-	enableConsole bool
-	hostname      string
-	ipAddress     string
+	*loggerCore
+	fields []Field // Fields dari With, ditambahkan ke setiap entry
+}
+
+// loggerCore holds the state shared by a logger and its children
+type loggerCore struct {
+	errorLog   *log.Logger
+	warningLog *log.Logger
+	successLog *log.Logger
+	infoLog    *log.Logger
+	sinks      []sinkEntry // Console, file, syslog dan sink dari LoggerConfig.Sinks
+	host       HostIdentity
+	redactor   *Redactor
+	spanCtx    SpanContextFunc
+	clock      Clock
+	ids        IDGenerator
+
+	// Level filtering
+	minLevel atomic.Int32
+
+	// Backpressure
+	overflowPolicy    OverflowPolicy
+	overflowTimeout   time.Duration
+	overflowKeepLevel LogLevel
+	dropped           atomic.Uint64
+
+	// Sampling (nil = nonaktif)
+	sampler *sampler
+
+	// Sync mode: sink ditulis oleh caller di bawah syncMu, tanpa worker
+	sync   bool
+	syncMu sync.Mutex
 
 	// Async logging
 	logChan   chan *logMessage
@@ -132,27 +257,6 @@ type Logger struct {
 	closed    chan struct{}
 }
 
-// getLocalIP returns the local IP address
-func getLocalIP() string {
-	conn, err := net.Dial("udp", "8.8.8.8:80")
-	if err != nil {
-		return "unknown"
-	}
-	defer conn.Close()
-
-	localAddr := conn.LocalAddr().(*net.UDPAddr)
-	return localAddr.IP.String()
-}
-
-// getHostname returns the hostname of the system
-func getHostname() string {
-	hostname, err := os.Hostname()
-	if err != nil {
-		return "unknown"
-	}
-	return hostname
-}
-
 // generateUUID generates a new UUID v7 (time-based) using google/uuid library
 func generateUUID() string {
 	u, err := uuid.NewV7()
@@ -162,17 +266,6 @@ func generateUUID() string {
 	return u.String()
 }
 
-// getUUIDFromContext extracts UUID from context or generates a new one
-func getUUIDFromContext(ctx context.Context) string {
-	if ctx == nil {
-		return generateUUID()
-	}
-	if uuid, ok := ctx.Value(UUIDKey).(string); ok && uuid != "" {
-		return uuid
-	}
-	return generateUUID()
-}
-
 // WithUUID adds UUID to context
 func WithUUID(ctx context.Context, uuid string) context.Context {
 	if ctx == nil {
@@ -283,21 +376,22 @@ func (l *Logger) StartFromRequest(r *http.Request, config StartConfig) context.C
 		ctx = WithServiceName(ctx, config.ServiceName)
 	}
 
-	// Generate or use existing UUID
-	if config.TransactionID == "" {
-		ctx = WithNewUUID(ctx)
-	} else {
-		ctx = WithUUID(ctx, config.TransactionID)
-		ctx = WithTransactionID(ctx, config.TransactionID)
+	// Correlation IDs dari header upstream, atau generate traceparent baru jika tidak ada
+	header := func(string) string { return "" }
+	if r != nil {
+		header = r.Header.Get
+	}
+	corr := extractCorrelation(header, config.Propagation, l.ids)
+	if config.TransactionID != "" {
+		corr.TransactionID = config.TransactionID
 	}
-
-	// Set trace ID if provided
 	if config.TraceID != "" {
-		ctx = WithTraceID(ctx, config.TraceID)
+		corr.TraceID = config.TraceID
 	}
+	ctx = WithCorrelation(ctx, corr)
 
 	// Set start time for execution time tracking
-	ctx = WithStartTime(ctx, time.Now())
+	ctx = WithStartTime(ctx, l.now())
 
 	// Set default level if not provided
 	level := config.Level
@@ -311,16 +405,10 @@ func (l *Logger) StartFromRequest(r *http.Request, config StartConfig) context.C
 		message = "Request started"
 	}
 
-	// Read request body if needed (optional)
+	// Read request body if needed (optional), dibatasi 4096 byte dan tanpa mengkonsumsi r.Body
 	body := config.Body
-	if body == "" && r != nil && r.Body != nil {
-		// Try to read body (but don't consume it)
-		bodyBytes, err := io.ReadAll(r.Body)
-		if err == nil && len(bodyBytes) > 0 {
-			body = string(bodyBytes)
-			// Restore body for further use
-			r.Body = io.NopCloser(strings.NewReader(body))
-		}
+	if body == "" && r != nil {
+		body = MiddlewareConfig{CaptureRequestBody: true}.ReadRequestBody(r)
 	}
 
 	// Log START event
@@ -365,7 +453,7 @@ func StartLogger(config *LoggerConfig) (*Logger, error) {
 	if config.Type == "" {
 		if config.LogFile != "" {
 			config.Type = LogTypeAll // Auto-set to "all" if logFile is provided
-		} else {
+		} else if len(config.Sinks) == 0 {
 			config.Type = LogTypeConsole // Default to console
 		}
 	}
@@ -379,40 +467,158 @@ func StartLogger(config *LoggerConfig) (*Logger, error) {
 	enableConsole := config.Type == LogTypeConsole || config.Type == LogTypeAll
 	enableFile := config.Type == LogTypeFile || config.Type == LogTypeAll
 
+	// Resolve output formats (default: text)
+	format, err := parseLogFormat(config.Format, FormatText)
+	if err != nil {
+		return nil, err
+	}
+	consoleFormat, err := parseLogFormat(config.ConsoleFormat, format)
+	if err != nil {
+		return nil, err
+	}
+	fileFormat, err := parseLogFormat(config.FileFormat, format)
+	if err != nil {
+		return nil, err
+	}
+
+	// Validate overflow policy (default: drop-newest)
+	overflowPolicy := config.OverflowPolicy
+	switch overflowPolicy {
+	case "":
+		overflowPolicy = OverflowDropNewest
+	case OverflowBlock, OverflowBlockTimeout, OverflowDropNewest, OverflowDropOldest, OverflowDropBelowLevel:
+	default:
+		return nil, fmt.Errorf("unsupported OverflowPolicy '%s'", overflowPolicy)
+	}
+
+	mode, err := parseLogMode(config.Mode)
+	if err != nil {
+		return nil, err
+	}
+
+	// Deteksi identitas host dari interface jaringan, env dan Downward API
+	host, err := DetectHostIdentity(config.Host)
+	if err != nil {
+		return nil, err
+	}
+
 	// Set buffer size (default: 1000)
 	bufferSize := config.BufferSize
 	if bufferSize <= 0 {
 		bufferSize = 1000 // Default buffer size
 	}
 
-	logger := &Logger{
-		errorLog:      log.New(os.Stderr, "", 0),
-		warningLog:    log.New(os.Stdout, "", 0),
-		successLog:    log.New(os.Stdout, "", 0),
-		infoLog:       log.New(os.Stdout, "", 0),
-		useFile:       false,
-		enableConsole: enableConsole,
-		hostname:      getHostname(),
-		ipAddress:     getLocalIP(),
-		logChan:       make(chan *logMessage, bufferSize), // Buffered channel with configurable capacity
-		closed:        make(chan struct{}),
+	logger := &Logger{loggerCore: &loggerCore{
+		errorLog:   log.New(os.Stderr, "", 0),
+		warningLog: log.New(os.Stdout, "", 0),
+		successLog: log.New(os.Stdout, "", 0),
+		infoLog:    log.New(os.Stdout, "", 0),
+		host:       host,
+		logChan:    make(chan *logMessage, bufferSize), // Buffered channel with configurable capacity
+		closed:     make(chan struct{}),
+		redactor:   config.Redactor,
+		spanCtx:    config.SpanContext,
+		clock:      config.Clock,
+		ids:        config.IDGenerator,
+		sync:       mode == ModeSync,
+
+		overflowPolicy:    overflowPolicy,
+		overflowTimeout:   config.OverflowTimeout,
+		overflowKeepLevel: resolveMinLevel(config.OverflowKeepLevel, LevelError),
+		sampler:           newSampler(config.Sampling),
+	}}
+	if logger.overflowTimeout <= 0 {
+		logger.overflowTimeout = 100 * time.Millisecond
+	}
+	if logger.clock == nil {
+		logger.clock = systemClock{}
+	}
+	if logger.ids == nil {
+		logger.ids = randomIDs{}
+	}
+
+	logger.SetLevel(config.MinLevel)
+
+	// Setup console output if enabled (DENGAN WARNA)
+	if enableConsole {
+		logger.addSink(NewConsoleSink(consoleFormat), config.ConsoleMinLevel)
 	}
 
 	// Setup file logging if enabled
 	// File akan ditulis tanpa warna (plain text)
 	if enableFile && config.LogFile != "" {
-		file, err := os.OpenFile(config.LogFile, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0666)
+		file, err := NewFileSink(FileSinkConfig{
+			Path:           config.LogFile,
+			Formatter:      fileFormat,
+			MaxSizeMB:      config.MaxSizeMB,
+			RotateInterval: config.RotateInterval,
+			MaxBackups:     config.MaxBackups,
+			MaxAgeDays:     config.MaxAgeDays,
+			Compress:       config.Compress,
+			Clock:          logger.clock,
+		})
 		if err != nil {
 			return nil, fmt.Errorf("failed to open log file: %w", err)
 		}
-		logger.file = file
-		logger.useFile = true
-		// File akan ditulis tanpa ANSI color codes (plain text)
+		logger.addSink(file, config.FileMinLevel)
+	}
+
+	// Setup syslog output if enabled
+	if config.Type == LogTypeSyslog {
+		syslog, err := NewSyslogSink(SyslogConfig{
+			Network:  config.SyslogNetwork,
+			Address:  config.SyslogAddress,
+			Facility: config.SyslogFacility,
+			AppName:  config.SyslogAppName,
+			Format:   config.SyslogFormat,
+			Hostname: logger.host.Hostname,
+		})
+		if err != nil {
+			logger.closeSinks()
+			return nil, err
+		}
+		logger.addSink(syslog, config.SyslogMinLevel)
 	}
 
-	// Start async worker goroutine
-	logger.wg.Add(1)
-	go logger.worker()
+	// Setup custom sinks
+	for _, sc := range config.Sinks {
+		if sc.Sink == nil {
+			logger.closeSinks()
+			return nil, fmt.Errorf("Sink is required in SinkConfig")
+		}
+		logger.addSink(sc.Sink, sc.MinLevel)
+	}
+
+	// Start async worker goroutine (ModeSync menulis langsung dari caller)
+	if !logger.sync {
+		logger.wg.Add(1)
+		go logger.worker()
+	}
+
+	// Start periodic summary of dropped entries
+	reportInterval := config.OverflowReportInterval
+	if reportInterval == 0 {
+		reportInterval = 10 * time.Second
+	}
+	if reportInterval > 0 {
+		go logger.reportDropped(reportInterval)
+	}
+
+	// Reopen file sinks on SIGHUP
+	if config.ReopenOnSIGHUP {
+		logger.reopenOnSIGHUP()
+	}
+
+	// Start periodic summary of sampled entries
+	if logger.sampler != nil {
+		sampledInterval := config.Sampling.ReportInterval
+		if sampledInterval == 0 {
+			sampledInterval = 10 * time.Second
+		}
+		if sampledInterval > 0 {
+			go logger.reportSampled(sampledInterval)
+		}
+	}
 
 	return logger, nil
 }
@@ -469,44 +675,33 @@ func (l *Logger) worker() {
 	}
 }
 
-// writeLog writes the log message to console and/or file
+// writeLog writes the log message to every sink
 func (l *Logger) writeLog(msg *logMessage) {
-	var formatted string
-
-	if msg.formatted != "" {
-		// Use pre-formatted message
-		formatted = msg.formatted
-	} else if msg.entry != nil {
-		// Use mandatory fields format
-		formatted = l.formatMandatoryMessage(*msg.entry)
-	} else {
-		// Use standard format with caller info from message
-		formatted = l.formatMessage(msg.level, msg.uuid, msg.message, msg.file, msg.line, msg.function, msg.args...)
-	}
-
-	// Write to console if enabled (DENGAN WARNA)
-	if l.enableConsole {
-		switch msg.level {
-		case "ERROR":
-			fmt.Fprintf(os.Stderr, "\033[31m%s\033[0m\n", formatted) // Red
-		case "WARNING":
-			fmt.Fprintf(os.Stdout, "\033[33m%s\033[0m\n", formatted) // Yellow
-		case "SUCCESS":
-			fmt.Fprintf(os.Stdout, "\033[32m%s\033[0m\n", formatted) // Green
-		case "INFO":
-			fmt.Fprintf(os.Stdout, "\033[36m%s\033[0m\n", formatted) // Cyan
-		default:
-			fmt.Println(formatted)
+	// Flush marker: semua message sebelumnya sudah ditulis
+	if msg.flushed != nil {
+		l.flushSinks()
+		if msg.sync {
+			msg.err = l.syncSinks()
 		}
+		if msg.reopen {
+			msg.err = l.reopenSinks()
+		}
+		close(msg.flushed)
+		return
 	}
 
-	// Write to file if enabled (TANPA WARNA - plain text)
-	if l.useFile && l.file != nil {
-		fmt.Fprintln(l.file, formatted) // Plain text, no color codes
-	}
+	entry := l.buildEntry(msg)
+	l.redactor.RedactEntry(entry)
+	l.writeSinks(levelFromString(msg.level), entry)
+}
+
+// addSink registers a sink; minLevel 0 berarti semua yang lolos MinLevel
+func (l *Logger) addSink(sink Sink, minLevel LogLevel) {
+	l.sinks = append(l.sinks, sinkEntry{sink: sink, minLevel: resolveMinLevel(minLevel, LevelTrace)})
 }
 
-// Close closes the log file and shuts down the async worker
+// Close flushes and closes every sink and shuts down the async worker.
+// Entry yang di-log setelah Close tidak ditulis dan dihitung di Dropped.
 func (l *Logger) Close() error {
 	var err error
 
@@ -516,16 +711,23 @@ func (l *Logger) Close() error {
 
 		// Wait for worker to finish processing remaining messages
 		l.wg.Wait()
+		l.dropQueued()
 
-		// Close log file
-		if l.file != nil {
-			err = l.file.Close()
-		}
+		// Close console, file, syslog and custom sinks (tunggu write ModeSync yang sedang berjalan)
+		l.syncMu.Lock()
+		err = l.closeSinks()
+		l.syncMu.Unlock()
 	})
 
 	return err
 }
 
+// exit flushes and closes the logger, then terminates the process (dipakai oleh Fatal*)
+func (l *Logger) exit() {
+	l.Close()
+	os.Exit(1)
+}
+
 // getCallerInfo returns the file, line number, and function name of the caller
 func getCallerInfo(skip int) (file string, line int, function string) {
 	pc, filePath, line, ok := runtime.Caller(skip)
@@ -552,21 +754,70 @@ func getCallerInfo(skip int) (file string, line int, function string) {
 	return file, line, function
 }
 
+// loggerPackage is the import path of this package, used to skip internal frames
+var loggerPackage = reflect.TypeOf(Logger{}).PkgPath()
+
+// getExternalCallerInfo returns the first caller outside this package and the Go runtime.
+// Dipakai oleh LogWithMandatoryFields yang bisa dipanggil dari berbagai kedalaman (Start, Stop, middleware);
+// frame runtime dilewati sehingga STOP dari panic recovery menunjuk ke kode yang panic.
+func getExternalCallerInfo() (file string, line int, function string) {
+	pcs := make([]uintptr, 16)
+	n := runtime.Callers(2, pcs)
+	frames := runtime.CallersFrames(pcs[:n])
+	for {
+		frame, more := frames.Next()
+		if !strings.HasPrefix(frame.Function, loggerPackage+".") && !strings.HasPrefix(frame.Function, "runtime.") {
+			parts := strings.Split(frame.Function, ".")
+			return filepath.Base(frame.File), frame.Line, parts[len(parts)-1]
+		}
+		if !more {
+			break
+		}
+	}
+	return "unknown", 0, "unknown"
+}
+
 // formatMessage formats the log message with timestamp, level, location, IP, hostname, and UUID
 // file, line, and function are captured at the call site (not in worker goroutine)
-func (l *Logger) formatMessage(level string, uuid string, message string, file string, line int, function string, args ...interface{}) string {
-	// Get current time with more detail
-	timestamp := time.Now().Format("2006-01-02 15:04:05.000")
+func formatMessage(entry *LogEntry) string {
+	// Format: [timestamp] [level] [uuid] [hostname@ip pod=namespace/pod node=node] [file:line:function] message key=value
+	formatted := fmt.Sprintf("[%s] [%s] [%s] [%s@%s%s] [%s:%d:%s] %s",
+		entry.Timestamp, entry.LogLevel, entry.UUID, entry.Hostname, entry.ServerIP, formatPodInfo(entry),
+		entry.File, entry.Line, entry.Function, entry.Message)
+	if entry.TraceID != "" {
+		formatted += " trace_id=" + entry.TraceID
+	}
+	if entry.SpanID != "" {
+		formatted += " span_id=" + entry.SpanID
+	}
+	if len(entry.Fields) > 0 {
+		formatted += " " + formatFields(entry.Fields)
+	}
+	return formatted
+}
 
-	formattedMsg := fmt.Sprintf(message, args...)
+// formatPodInfo returns the " pod=namespace/pod node=node" suffix of the host tag, atau "" di luar Kubernetes
+func formatPodInfo(entry *LogEntry) string {
+	var b strings.Builder
+	if entry.PodName != "" {
+		b.WriteString(" pod=" + qualifiedPodName(entry))
+	}
+	if entry.NodeName != "" {
+		b.WriteString(" node=" + entry.NodeName)
+	}
+	return b.String()
+}
 
-	// Format: [timestamp] [level] [uuid] [hostname@ip] [file:line:function] message
-	return fmt.Sprintf("[%s] [%s] [%s] [%s@%s] [%s:%d:%s] %s",
-		timestamp, level, uuid, l.hostname, l.ipAddress, file, line, function, formattedMsg)
+// qualifiedPodName returns namespace/pod, atau pod jika namespace tidak diketahui
+func qualifiedPodName(entry *LogEntry) string {
+	if entry.Namespace == "" {
+		return entry.PodName
+	}
+	return entry.Namespace + "/" + entry.PodName
 }
 
 // formatMandatoryMessage formats the log message with all mandatory fields in a readable format
-func (l *Logger) formatMandatoryMessage(entry LogEntry) string {
+func formatMandatoryMessage(entry LogEntry) string {
 	var parts []string
 
 	// Timestamp and Level
@@ -602,6 +853,10 @@ func (l *Logger) formatMandatoryMessage(entry LogEntry) string {
 		parts = append(parts, fmt.Sprintf("TraceID: %s", entry.TraceID))
 	}
 
+	if entry.SpanID != "" {
+		parts = append(parts, fmt.Sprintf("SpanID: %s", entry.SpanID))
+	}
+
 	// Execution time
 	if entry.ExecutionTime != "0ms" && entry.ExecutionTime != "" {
 		parts = append(parts, fmt.Sprintf("Duration: %s", entry.ExecutionTime))
@@ -610,11 +865,24 @@ func (l *Logger) formatMandatoryMessage(entry LogEntry) string {
 	// Server IP
 	parts = append(parts, fmt.Sprintf("IP: %s", entry.ServerIP))
 
+	// Kubernetes pod dan node (if present)
+	if entry.PodName != "" {
+		parts = append(parts, fmt.Sprintf("Pod: %s", qualifiedPodName(&entry)))
+	}
+	if entry.NodeName != "" {
+		parts = append(parts, fmt.Sprintf("Node: %s", entry.NodeName))
+	}
+
 	// Body (if present)
 	if entry.Body != "" {
 		parts = append(parts, fmt.Sprintf("Body: %s", entry.Body))
 	}
 
+	// Structured fields
+	if len(entry.Fields) > 0 {
+		parts = append(parts, formatFields(entry.Fields))
+	}
+
 	// Message
 	parts = append(parts, fmt.Sprintf("→ %s", entry.Message))
 
@@ -622,7 +890,7 @@ func (l *Logger) formatMandatoryMessage(entry LogEntry) string {
 }
 
 // writeToBoth sends log message to async channel (non-blocking)
-func (l *Logger) writeToBoth(level string, uuid string, message string, args ...interface{}) {
+func (l *Logger) writeToBoth(level string, uuid string, trace Correlation, fields []Field, message string, args ...interface{}) {
 	// Get caller information (skip 3 levels: writeToBoth -> Error/Warning/etc -> user code)
 	file, line, function := getCallerInfo(3)
 
@@ -632,173 +900,346 @@ func (l *Logger) writeToBoth(level string, uuid string, message string, args ...
 		uuid:     uuid,
 		message:  message,
 		args:     args,
+		fields:   fields,
 		file:     file,
 		line:     line,
 		function: function,
-	}
 
-	// Send to channel (non-blocking if channel is full, we'll drop the message)
-	select {
-	case l.logChan <- msg:
-		// Message sent successfully
-	default:
-		// Channel is full, log to stderr as fallback (shouldn't happen in normal operation)
-		fmt.Fprintf(os.Stderr, "[LOGGER ERROR] Log channel is full, dropping message: %s\n", message)
+		traceID:    trace.TraceID,
+		spanID:     trace.SpanID,
+		traceFlags: trace.TraceFlags,
 	}
+
+	// Send to channel (behaviour when full depends on OverflowPolicy)
+	l.enqueue(msg)
 }
 
 // Error logs an error message
 func (l *Logger) Error(message string, args ...interface{}) {
-	l.writeToBoth("ERROR", generateUUID(), message, args...)
+	if !l.enabled(LevelError) {
+		return
+	}
+	l.writeToBoth("ERROR", l.ids.NewUUID(), Correlation{}, l.fields, message, args...)
 }
 
 // Warning logs a warning message
 func (l *Logger) Warning(message string, args ...interface{}) {
-	l.writeToBoth("WARNING", generateUUID(), message, args...)
+	if !l.enabled(LevelWarning) {
+		return
+	}
+	l.writeToBoth("WARNING", l.ids.NewUUID(), Correlation{}, l.fields, message, args...)
 }
 
 // Success logs a success message
 func (l *Logger) Success(message string, args ...interface{}) {
-	l.writeToBoth("SUCCESS", generateUUID(), message, args...)
+	if !l.enabled(LevelSuccess) {
+		return
+	}
+	l.writeToBoth("SUCCESS", l.ids.NewUUID(), Correlation{}, l.fields, message, args...)
 }
 
 // Info logs an info message
 func (l *Logger) Info(message string, args ...interface{}) {
-	l.writeToBoth("INFO", generateUUID(), message, args...)
+	if !l.enabled(LevelInfo) {
+		return
+	}
+	l.writeToBoth("INFO", l.ids.NewUUID(), Correlation{}, l.fields, message, args...)
 }
 
 // Errorf logs a formatted error message
 func (l *Logger) Errorf(format string, args ...interface{}) {
+	if !l.enabled(LevelError) {
+		return
+	}
 	// Get caller information (skip 2 levels: Errorf -> user code)
 	file, line, function := getCallerInfo(2)
 	msg := &logMessage{
 		level:    "ERROR",
-		uuid:     generateUUID(),
+		uuid:     l.ids.NewUUID(),
 		message:  format,
 		args:     args,
+		fields:   l.fields,
 		file:     file,
 		line:     line,
 		function: function,
 	}
-	select {
-	case l.logChan <- msg:
-	default:
-		fmt.Fprintf(os.Stderr, "[LOGGER ERROR] Log channel is full, dropping message: %s\n", format)
-	}
+	l.enqueue(msg)
 }
 
 // Warningf logs a formatted warning message
 func (l *Logger) Warningf(format string, args ...interface{}) {
+	if !l.enabled(LevelWarning) {
+		return
+	}
 	// Get caller information (skip 2 levels: Warningf -> user code)
 	file, line, function := getCallerInfo(2)
 	msg := &logMessage{
 		level:    "WARNING",
-		uuid:     generateUUID(),
+		uuid:     l.ids.NewUUID(),
 		message:  format,
 		args:     args,
+		fields:   l.fields,
 		file:     file,
 		line:     line,
 		function: function,
 	}
-	select {
-	case l.logChan <- msg:
-	default:
-		fmt.Fprintf(os.Stderr, "[LOGGER ERROR] Log channel is full, dropping message: %s\n", format)
-	}
+	l.enqueue(msg)
 }
 
 // Successf logs a formatted success message
 func (l *Logger) Successf(format string, args ...interface{}) {
+	if !l.enabled(LevelSuccess) {
+		return
+	}
 	// Get caller information (skip 2 levels: Successf -> user code)
 	file, line, function := getCallerInfo(2)
 	msg := &logMessage{
 		level:    "SUCCESS",
-		uuid:     generateUUID(),
+		uuid:     l.ids.NewUUID(),
 		message:  format,
 		args:     args,
+		fields:   l.fields,
 		file:     file,
 		line:     line,
 		function: function,
 	}
-	select {
-	case l.logChan <- msg:
-	default:
-		fmt.Fprintf(os.Stderr, "[LOGGER ERROR] Log channel is full, dropping message: %s\n", format)
-	}
+	l.enqueue(msg)
 }
 
 // Infof logs a formatted info message
 func (l *Logger) Infof(format string, args ...interface{}) {
+	if !l.enabled(LevelInfo) {
+		return
+	}
 	// Get caller information (skip 2 levels: Infof -> user code)
 	file, line, function := getCallerInfo(2)
 	msg := &logMessage{
 		level:    "INFO",
-		uuid:     generateUUID(),
+		uuid:     l.ids.NewUUID(),
 		message:  format,
 		args:     args,
+		fields:   l.fields,
 		file:     file,
 		line:     line,
 		function: function,
 	}
-	select {
-	case l.logChan <- msg:
-	default:
-		fmt.Fprintf(os.Stderr, "[LOGGER ERROR] Log channel is full, dropping message: %s\n", format)
-	}
+	l.enqueue(msg)
 }
 
 // ErrorCtx logs an error message with context
 func (l *Logger) ErrorCtx(ctx context.Context, message string, args ...interface{}) {
-	uuid := getUUIDFromContext(ctx)
-	l.writeToBoth("ERROR", uuid, message, args...)
+	if !l.enabled(LevelError) {
+		return
+	}
+	uuid := l.uuidFromContext(ctx)
+	l.writeToBoth("ERROR", uuid, l.traceContext(ctx), l.entryFields(ctx), message, args...)
 }
 
 // WarningCtx logs a warning message with context
 func (l *Logger) WarningCtx(ctx context.Context, message string, args ...interface{}) {
-	uuid := getUUIDFromContext(ctx)
-	l.writeToBoth("WARNING", uuid, message, args...)
+	if !l.enabled(LevelWarning) {
+		return
+	}
+	uuid := l.uuidFromContext(ctx)
+	l.writeToBoth("WARNING", uuid, l.traceContext(ctx), l.entryFields(ctx), message, args...)
 }
 
 // SuccessCtx logs a success message with context
 func (l *Logger) SuccessCtx(ctx context.Context, message string, args ...interface{}) {
-	uuid := getUUIDFromContext(ctx)
-	l.writeToBoth("SUCCESS", uuid, message, args...)
+	if !l.enabled(LevelSuccess) {
+		return
+	}
+	uuid := l.uuidFromContext(ctx)
+	l.writeToBoth("SUCCESS", uuid, l.traceContext(ctx), l.entryFields(ctx), message, args...)
 }
 
 // InfoCtx logs an info message with context
 func (l *Logger) InfoCtx(ctx context.Context, message string, args ...interface{}) {
-	uuid := getUUIDFromContext(ctx)
-	l.writeToBoth("INFO", uuid, message, args...)
+	if !l.enabled(LevelInfo) {
+		return
+	}
+	uuid := l.uuidFromContext(ctx)
+	l.writeToBoth("INFO", uuid, l.traceContext(ctx), l.entryFields(ctx), message, args...)
 }
 
 // ErrorfCtx logs a formatted error message with context
 func (l *Logger) ErrorfCtx(ctx context.Context, format string, args ...interface{}) {
-	l.ErrorCtx(ctx, format, args...)
+	if !l.enabled(LevelError) {
+		return
+	}
+	l.writeToBoth("ERROR", l.uuidFromContext(ctx), l.traceContext(ctx), l.entryFields(ctx), format, args...)
 }
 
 // WarningfCtx logs a formatted warning message with context
 func (l *Logger) WarningfCtx(ctx context.Context, format string, args ...interface{}) {
-	l.WarningCtx(ctx, format, args...)
+	if !l.enabled(LevelWarning) {
+		return
+	}
+	l.writeToBoth("WARNING", l.uuidFromContext(ctx), l.traceContext(ctx), l.entryFields(ctx), format, args...)
 }
 
 // SuccessfCtx logs a formatted success message with context
 func (l *Logger) SuccessfCtx(ctx context.Context, format string, args ...interface{}) {
-	l.SuccessCtx(ctx, format, args...)
+	if !l.enabled(LevelSuccess) {
+		return
+	}
+	l.writeToBoth("SUCCESS", l.uuidFromContext(ctx), l.traceContext(ctx), l.entryFields(ctx), format, args...)
 }
 
 // InfofCtx logs a formatted info message with context
 func (l *Logger) InfofCtx(ctx context.Context, format string, args ...interface{}) {
-	l.InfoCtx(ctx, format, args...)
+	if !l.enabled(LevelInfo) {
+		return
+	}
+	l.writeToBoth("INFO", l.uuidFromContext(ctx), l.traceContext(ctx), l.entryFields(ctx), format, args...)
+}
+
+// Debug logs a debug message
+func (l *Logger) Debug(message string, args ...interface{}) {
+	if !l.enabled(LevelDebug) {
+		return
+	}
+	l.writeToBoth("DEBUG", l.ids.NewUUID(), Correlation{}, l.fields, message, args...)
+}
+
+// Debugf logs a formatted debug message
+func (l *Logger) Debugf(format string, args ...interface{}) {
+	if !l.enabled(LevelDebug) {
+		return
+	}
+	l.writeToBoth("DEBUG", l.ids.NewUUID(), Correlation{}, l.fields, format, args...)
+}
+
+// DebugCtx logs a debug message with context
+func (l *Logger) DebugCtx(ctx context.Context, message string, args ...interface{}) {
+	if !l.enabled(LevelDebug) {
+		return
+	}
+	l.writeToBoth("DEBUG", l.uuidFromContext(ctx), l.traceContext(ctx), l.entryFields(ctx), message, args...)
+}
+
+// DebugfCtx logs a formatted debug message with context
+func (l *Logger) DebugfCtx(ctx context.Context, format string, args ...interface{}) {
+	if !l.enabled(LevelDebug) {
+		return
+	}
+	l.writeToBoth("DEBUG", l.uuidFromContext(ctx), l.traceContext(ctx), l.entryFields(ctx), format, args...)
+}
+
+// Trace logs a trace message
+func (l *Logger) Trace(message string, args ...interface{}) {
+	if !l.enabled(LevelTrace) {
+		return
+	}
+	l.writeToBoth("TRACE", l.ids.NewUUID(), Correlation{}, l.fields, message, args...)
+}
+
+// Tracef logs a formatted trace message
+func (l *Logger) Tracef(format string, args ...interface{}) {
+	if !l.enabled(LevelTrace) {
+		return
+	}
+	l.writeToBoth("TRACE", l.ids.NewUUID(), Correlation{}, l.fields, format, args...)
+}
+
+// TraceCtx logs a trace message with context
+func (l *Logger) TraceCtx(ctx context.Context, message string, args ...interface{}) {
+	if !l.enabled(LevelTrace) {
+		return
+	}
+	l.writeToBoth("TRACE", l.uuidFromContext(ctx), l.traceContext(ctx), l.entryFields(ctx), message, args...)
+}
+
+// TracefCtx logs a formatted trace message with context
+func (l *Logger) TracefCtx(ctx context.Context, format string, args ...interface{}) {
+	if !l.enabled(LevelTrace) {
+		return
+	}
+	l.writeToBoth("TRACE", l.uuidFromContext(ctx), l.traceContext(ctx), l.entryFields(ctx), format, args...)
+}
+
+// Fatal logs a fatal message, flushes all pending logs, closes the logger and exits with code 1
+func (l *Logger) Fatal(message string, args ...interface{}) {
+	l.writeToBoth("FATAL", l.ids.NewUUID(), Correlation{}, l.fields, message, args...)
+	l.exit()
+}
+
+// Fatalf logs a formatted fatal message, flushes all pending logs, closes the logger and exits with code 1
+func (l *Logger) Fatalf(format string, args ...interface{}) {
+	l.writeToBoth("FATAL", l.ids.NewUUID(), Correlation{}, l.fields, format, args...)
+	l.exit()
+}
+
+// FatalCtx logs a fatal message with context, flushes all pending logs, closes the logger and exits with code 1
+func (l *Logger) FatalCtx(ctx context.Context, message string, args ...interface{}) {
+	l.writeToBoth("FATAL", l.uuidFromContext(ctx), l.traceContext(ctx), l.entryFields(ctx), message, args...)
+	l.exit()
+}
+
+// FatalfCtx logs a formatted fatal message with context, flushes all pending logs, closes the logger and exits with code 1
+func (l *Logger) FatalfCtx(ctx context.Context, format string, args ...interface{}) {
+	l.writeToBoth("FATAL", l.uuidFromContext(ctx), l.traceContext(ctx), l.entryFields(ctx), format, args...)
+	l.exit()
+}
+
+// Panic logs a panic message, flushes all pending logs and panics with the message
+func (l *Logger) Panic(message string, args ...interface{}) {
+	l.writeToBoth("PANIC", l.ids.NewUUID(), Correlation{}, l.fields, message, args...)
+	l.Flush(context.Background())
+	panic(fmt.Sprintf(message, args...))
+}
+
+// Panicf logs a formatted panic message, flushes all pending logs and panics with the message
+func (l *Logger) Panicf(format string, args ...interface{}) {
+	l.writeToBoth("PANIC", l.ids.NewUUID(), Correlation{}, l.fields, format, args...)
+	l.Flush(context.Background())
+	panic(fmt.Sprintf(format, args...))
+}
+
+// PanicCtx logs a panic message with context, flushes all pending logs and panics with the message
+func (l *Logger) PanicCtx(ctx context.Context, message string, args ...interface{}) {
+	l.writeToBoth("PANIC", l.uuidFromContext(ctx), l.traceContext(ctx), l.entryFields(ctx), message, args...)
+	l.Flush(context.Background())
+	panic(fmt.Sprintf(message, args...))
+}
+
+// PanicfCtx logs a formatted panic message with context, flushes all pending logs and panics with the message
+func (l *Logger) PanicfCtx(ctx context.Context, format string, args ...interface{}) {
+	l.writeToBoth("PANIC", l.uuidFromContext(ctx), l.traceContext(ctx), l.entryFields(ctx), format, args...)
+	l.Flush(context.Background())
+	panic(fmt.Sprintf(format, args...))
 }
 
 // LogWithMandatoryFields logs with all mandatory fields
 func (l *Logger) LogWithMandatoryFields(ctx context.Context, level string, flag LogFlag, message string, body string) {
-	now := time.Now()
-	timestamp := now.Format("2006-01-02 15:04:05.000")
+	if !l.enabled(levelFromString(level)) {
+		return
+	}
+
+	entry := l.newMandatoryEntry(ctx, level, flag, message, body)
+	entry.File, entry.Line, entry.Function = getExternalCallerInfo()
+
+	msg := &logMessage{
+		level: level,
+		entry: entry,
+	}
+
+	// Send to async channel (behaviour when full depends on OverflowPolicy)
+	l.enqueue(msg)
+}
+
+// newMandatoryEntry builds a LogEntry with all mandatory fields from context (tanpa caller info)
+func (l *Logger) newMandatoryEntry(ctx context.Context, level string, flag LogFlag, message string, body string) *LogEntry {
+	now := l.now()
+	timestamp := now.Format(timestampLayout)
 
 	// Extract all values from context
-	transactionID := getValueFromContext(ctx, TransactionIDKey, getUUIDFromContext(ctx))
-	traceID := getValueFromContext(ctx, TraceIDKey, getUUIDFromContext(ctx))
+	transactionID := getValueFromContext(ctx, TransactionIDKey, l.uuidFromContext(ctx))
+	trace := l.traceContext(ctx)
+	traceID := trace.TraceID
+	if traceID == "" {
+		traceID = l.uuidFromContext(ctx)
+	}
 	serviceName := getValueFromContext(ctx, ServiceNameKey, "unknown")
 	endpoint := getValueFromContext(ctx, EndpointKey, "unknown")
 	methodType := getValueFromContext(ctx, MethodKey, "unknown")
@@ -810,7 +1251,8 @@ func (l *Logger) LogWithMandatoryFields(ctx context.Context, level string, flag
 		executionTime = fmt.Sprintf("%dms", duration.Milliseconds())
 	}
 
-	entry := LogEntry{
+	return &LogEntry{
+		Time:          now,
 		Timestamp:     timestamp,
 		LogLevel:      level,
 		TransactionID: transactionID,
@@ -818,29 +1260,15 @@ func (l *Logger) LogWithMandatoryFields(ctx context.Context, level string, flag
 		Endpoint:      endpoint,
 		MethodType:    methodType,
 		ExecutionTime: executionTime,
-		ServerIP:      l.ipAddress,
+		ServerIP:      l.host.IP,
 		TraceID:       traceID,
+		SpanID:        trace.SpanID,
+		TraceFlags:    trace.TraceFlags,
 		Body:          body,
 		Flag:          flag,
 		Message:       message,
-	}
-
-	formatted := l.formatMandatoryMessage(entry)
-
-	// Send to async channel
-	msg := &logMessage{
-		level:     level,
-		entry:     &entry,
-		formatted: formatted,
-	}
-
-	// Send to channel (non-blocking if channel is full)
-	select {
-	case l.logChan <- msg:
-		// Message sent successfully
-	default:
-		// Channel is full, log to stderr as fallback
-		fmt.Fprintf(os.Stderr, "[LOGGER ERROR] Log channel is full, dropping message: %s\n", formatted)
+		UUID:          getValueFromContext(ctx, UUIDKey, transactionID),
+		Fields:        l.entryFields(ctx),
 	}
 }
 
@@ -869,7 +1297,7 @@ func (l *Logger) Start(ctx context.Context, config StartConfig) context.Context
 
 	// Generate or use existing UUID
 	if config.TransactionID == "" {
-		ctx = WithNewUUID(ctx)
+		ctx = WithUUID(ctx, l.ids.NewUUID())
 	} else {
 		ctx = WithUUID(ctx, config.TransactionID)
 		ctx = WithTransactionID(ctx, config.TransactionID)
@@ -896,7 +1324,7 @@ func (l *Logger) Start(ctx context.Context, config StartConfig) context.Context
 	}
 
 	// Set start time for execution time tracking
-	ctx = WithStartTime(ctx, time.Now())
+	ctx = WithStartTime(ctx, l.now())
 
 	// Set default level if not provided
 	level := config.Level
@@ -0,0 +1,325 @@
+// Package logtest provides an observer Logger and assertion helpers for unit tests:
+// entry dicatat sebagai struct (bukan teks di stdout), sehingga test bisa memeriksa level,
+// message, fields, LogEntry dan caller yang ditulis oleh handler.
+package logtest
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/funxdofficial/golang-module-syslog/logger"
+)
+
+// Entry is a recorded log entry
+type Entry struct {
+	logger.LogEntry
+}
+
+// Level returns the level of the entry
+func (e Entry) Level() logger.LogLevel {
+	level, _ := logger.ParseLevel(e.LogEntry.LogLevel)
+	return level
+}
+
+// Field returns the value of the structured field with the given key (field terakhir jika ada duplikat)
+func (e Entry) Field(key string) (interface{}, bool) {
+	for i := len(e.Fields) - 1; i >= 0; i-- {
+		if e.Fields[i].Key == key {
+			return e.Fields[i].Value, true
+		}
+	}
+	return nil, false
+}
+
+// Caller returns the call site of the entry as file:line
+func (e Entry) Caller() string {
+	return fmt.Sprintf("%s:%d", e.File, e.Line)
+}
+
+// String formats the entry like the text format
+func (e Entry) String() string {
+	return logger.FormatText.Format(&e.LogEntry)
+}
+
+// Entries is a list of recorded entries with filter methods
+type Entries []Entry
+
+// Level returns the entries with the given level
+func (es Entries) Level(level logger.LogLevel) Entries {
+	return es.Filter(func(e Entry) bool { return e.LogLevel == level.String() })
+}
+
+// Message returns the entries whose message contains substr
+func (es Entries) Message(substr string) Entries {
+	return es.Filter(func(e Entry) bool { return strings.Contains(e.Message, substr) })
+}
+
+// Flag returns the entries with the given flag (START, STOP, ACCESS)
+func (es Entries) Flag(flag logger.LogFlag) Entries {
+	return es.Filter(func(e Entry) bool { return e.Flag == flag })
+}
+
+// TransactionID returns the entries of one transaction: entry mandatory dengan transaction ID
+// tersebut dan entry *Ctx yang UUID-nya sama
+func (es Entries) TransactionID(id string) Entries {
+	return es.Filter(func(e Entry) bool { return e.TransactionID == id || (e.TransactionID == "" && e.UUID == id) })
+}
+
+// Field returns the entries that have a field with the given key and value (dibandingkan dengan fmt %v)
+func (es Entries) Field(key string, value interface{}) Entries {
+	want := fmt.Sprint(value)
+	return es.Filter(func(e Entry) bool {
+		v, ok := e.Field(key)
+		return ok && fmt.Sprint(v) == want
+	})
+}
+
+// Filter returns the entries for which keep returns true
+func (es Entries) Filter(keep func(Entry) bool) Entries {
+	var filtered Entries
+	for _, e := range es {
+		if keep(e) {
+			filtered = append(filtered, e)
+		}
+	}
+	return filtered
+}
+
+// Messages returns the message of every entry
+func (es Entries) Messages() []string {
+	messages := make([]string, len(es))
+	for i, e := range es {
+		messages[i] = e.Message
+	}
+	return messages
+}
+
+// Observer is a logger.Sink that records every entry in memory.
+// Semua method baca menunggu entry yang sudah di-log sebelumnya (Flush), sehingga hasilnya
+// langsung bisa diperiksa setelah handler selesai tanpa sleep.
+type Observer struct {
+	mu      sync.Mutex
+	entries []Entry
+	logger  *logger.Logger
+}
+
+// New creates a Logger that records every entry (semua level, tanpa output ke console) and its Observer.
+// Logger berjalan dengan ModeSync dan di-close dengan t.Cleanup; jika test gagal, semua entry ditulis ke t.Log.
+//
+//	appLogger, logs := logtest.New(t)
+//	handler := NewHandler(appLogger)
+//	handler.ServeHTTP(w, r)
+//	logs.AssertLogged(t, logger.LevelError, "payment failed")
+func New(t testing.TB) (*logger.Logger, *Observer) {
+	t.Helper()
+	return NewWithConfig(t, &logger.LoggerConfig{MinLevel: logger.LevelTrace})
+}
+
+// NewWithConfig is like New but starts the logger with config (misalnya MinLevel, Redactor atau Sampling).
+// Observer ditambahkan ke config.Sinks; jika Type dan LogFile kosong tidak ada output lain.
+// Mode default-nya ModeSync (entry tercatat sebelum method log kembali) dan OverflowPolicy
+// default-nya OverflowBlock sehingga tidak ada entry yang di-drop.
+func NewWithConfig(t testing.TB, config *logger.LoggerConfig) (*logger.Logger, *Observer) {
+	t.Helper()
+	observer := &Observer{}
+	cfg := *config
+	cfg.Sinks = append(append([]logger.SinkConfig(nil), config.Sinks...), logger.SinkConfig{Sink: observer})
+	if cfg.Mode == "" {
+		cfg.Mode = logger.ModeSync
+	}
+	if cfg.OverflowPolicy == "" {
+		// Test tidak boleh kehilangan entry karena channel penuh
+		cfg.OverflowPolicy = logger.OverflowBlock
+	}
+
+	l, err := logger.StartLogger(&cfg)
+	if err != nil {
+		t.Fatalf("logtest: failed to start logger: %v", err)
+	}
+	observer.logger = l
+
+	t.Cleanup(func() {
+		l.Close()
+		if t.Failed() {
+			for _, e := range observer.snapshot() {
+				t.Log(e.String())
+			}
+		}
+	})
+	return l, observer
+}
+
+// Write records the entry
+func (o *Observer) Write(entry *logger.LogEntry) error {
+	e := Entry{LogEntry: *entry}
+	e.Fields = append([]logger.Field(nil), entry.Fields...)
+
+	o.mu.Lock()
+	o.entries = append(o.entries, e)
+	o.mu.Unlock()
+	return nil
+}
+
+// Flush is a no-op, entry langsung disimpan di memory
+func (o *Observer) Flush() error {
+	return nil
+}
+
+// Close is a no-op, entry tetap bisa dibaca setelah logger di-close
+func (o *Observer) Close() error {
+	return nil
+}
+
+// All returns every entry logged so far
+func (o *Observer) All() Entries {
+	if o.logger != nil {
+		o.logger.Flush(context.Background())
+	}
+	return o.snapshot()
+}
+
+// Len returns the number of entries logged so far
+func (o *Observer) Len() int {
+	return len(o.All())
+}
+
+// TakeAll returns every entry logged so far and resets the observer
+func (o *Observer) TakeAll() Entries {
+	entries := o.All()
+	o.mu.Lock()
+	o.entries = o.entries[len(entries):]
+	o.mu.Unlock()
+	return entries
+}
+
+// Reset removes every recorded entry
+func (o *Observer) Reset() {
+	o.TakeAll()
+}
+
+// snapshot returns a copy of the recorded entries tanpa menunggu worker
+func (o *Observer) snapshot() Entries {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	return append(Entries(nil), o.entries...)
+}
+
+// AssertLogged fails the test unless an entry with the given level and a message containing
+// msgSubstring was logged, dan mengembalikan entry pertama yang cocok
+func (o *Observer) AssertLogged(t testing.TB, level logger.LogLevel, msgSubstring string) Entry {
+	t.Helper()
+	all := o.All()
+	matches := all.Level(level).Message(msgSubstring)
+	if len(matches) == 0 {
+		t.Errorf("logtest: no %s entry containing %q; logged:\n%s", level, msgSubstring, describe(all))
+		return Entry{}
+	}
+	return matches[0]
+}
+
+// AssertNotLogged fails the test if an entry with the given level and a message containing
+// msgSubstring was logged
+func (o *Observer) AssertNotLogged(t testing.TB, level logger.LogLevel, msgSubstring string) {
+	t.Helper()
+	if matches := o.All().Level(level).Message(msgSubstring); len(matches) > 0 {
+		t.Errorf("logtest: unexpected %s entry containing %q:\n%s", level, msgSubstring, describe(matches))
+	}
+}
+
+// AssertCount fails the test unless exactly n entries were logged with the given level
+func (o *Observer) AssertCount(t testing.TB, level logger.LogLevel, n int) {
+	t.Helper()
+	all := o.All()
+	if matches := all.Level(level); len(matches) != n {
+		t.Errorf("logtest: got %d %s entries, want %d; logged:\n%s", len(matches), level, n, describe(all))
+	}
+}
+
+// AssertTransaction fails the test unless transaction id has a START and a STOP entry,
+// dan mengembalikan semua entry dari transaksi tersebut
+func (o *Observer) AssertTransaction(t testing.TB, id string) Entries {
+	t.Helper()
+	entries := o.All().TransactionID(id)
+	if len(entries.Flag(logger.FlagStart)) == 0 || len(entries.Flag(logger.FlagStop)) == 0 {
+		t.Errorf("logtest: transaction %q has no START/STOP pair; logged:\n%s", id, describe(entries))
+	}
+	return entries
+}
+
+// StepClock is a logger.Clock that starts at a fixed time and advances by step on every call,
+// sehingga timestamp dan durasi sama di setiap run
+type StepClock struct {
+	mu   sync.Mutex
+	now  time.Time
+	step time.Duration
+}
+
+// NewStepClock creates a StepClock; Now pertama mengembalikan start
+//
+//	appLogger, logs := logtest.NewWithConfig(t, &logger.LoggerConfig{
+//		Clock:       logtest.NewStepClock(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), time.Millisecond),
+//		IDGenerator: logtest.NewSequenceIDs(),
+//	})
+func NewStepClock(start time.Time, step time.Duration) *StepClock {
+	return &StepClock{now: start, step: step}
+}
+
+// Now returns the current time of the clock and advances it by step
+func (c *StepClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	now := c.now
+	c.now = c.now.Add(c.step)
+	return now
+}
+
+// SequenceIDs is a logger.IDGenerator that returns sequential IDs (1, 2, 3, ...) per jenis ID
+type SequenceIDs struct {
+	uuids, traces, spans atomic.Uint64
+}
+
+// NewSequenceIDs creates a SequenceIDs starting at 1
+func NewSequenceIDs() *SequenceIDs {
+	return &SequenceIDs{}
+}
+
+// NewUUID returns the next UUID, misalnya 00000000-0000-7000-8000-000000000001
+func (g *SequenceIDs) NewUUID() string {
+	return fmt.Sprintf("00000000-0000-7000-8000-%012x", g.uuids.Add(1))
+}
+
+// NewTraceID returns the next trace ID (32 hex)
+func (g *SequenceIDs) NewTraceID() string {
+	return fmt.Sprintf("%032x", g.traces.Add(1))
+}
+
+// NewSpanID returns the next span ID (16 hex)
+func (g *SequenceIDs) NewSpanID() string {
+	return fmt.Sprintf("%016x", g.spans.Add(1))
+}
+
+// maxDescribed is the maximum number of entries in a failure message
+const maxDescribed = 50
+
+// describe formats entries for a failure message, paling banyak maxDescribed entry terakhir
+func describe(entries Entries) string {
+	if len(entries) == 0 {
+		return "  (no entries)"
+	}
+	var b strings.Builder
+	if len(entries) > maxDescribed {
+		fmt.Fprintf(&b, "  ... %d earlier entries\n", len(entries)-maxDescribed)
+		entries = entries[len(entries)-maxDescribed:]
+	}
+	for _, e := range entries {
+		b.WriteString("  ")
+		b.WriteString(e.String())
+		b.WriteByte('\n')
+	}
+	return b.String()
+}
@@ -0,0 +1,193 @@
+package logtest_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/funxdofficial/golang-module-syslog/logger"
+	"github.com/funxdofficial/golang-module-syslog/logger/logtest"
+)
+
+// recordingTB captures assertion failures instead of failing the test
+type recordingTB struct {
+	testing.TB
+	errors []string
+}
+
+func (r *recordingTB) Helper() {}
+
+func (r *recordingTB) Errorf(format string, args ...interface{}) {
+	r.errors = append(r.errors, fmt.Sprintf(format, args...))
+}
+
+func TestNewRecordsEveryLevel(t *testing.T) {
+	appLogger, logs := logtest.New(t)
+
+	appLogger.Trace("trace %d", 1)
+	appLogger.Debug("debug")
+	appLogger.Info("info")
+	appLogger.With(logger.String("order_id", "ORD-1")).Warning("slow payment")
+	appLogger.Error("payment failed")
+
+	if logs.Len() != 5 {
+		t.Fatalf("Len = %d, want 5", logs.Len())
+	}
+	entry := logs.AssertLogged(t, logger.LevelWarning, "slow")
+	if v, ok := entry.Field("order_id"); !ok || v != "ORD-1" {
+		t.Errorf("order_id = %v, %v", v, ok)
+	}
+	if !strings.HasPrefix(entry.Caller(), "logtest_test.go:") || entry.Function != "TestNewRecordsEveryLevel" {
+		t.Errorf("caller = %s %s", entry.Caller(), entry.Function)
+	}
+	logs.AssertNotLogged(t, logger.LevelError, "retry")
+	logs.AssertCount(t, logger.LevelError, 1)
+
+	if got := logs.All().Level(logger.LevelTrace).Messages(); len(got) != 1 || got[0] != "trace 1" {
+		t.Errorf("trace messages = %v", got)
+	}
+}
+
+func TestAssertionsReportFailures(t *testing.T) {
+	appLogger, logs := logtest.New(t)
+	appLogger.Info("hello")
+
+	rec := &recordingTB{TB: t}
+	logs.AssertLogged(rec, logger.LevelError, "hello")
+	logs.AssertNotLogged(rec, logger.LevelInfo, "hello")
+	logs.AssertCount(rec, logger.LevelInfo, 2)
+	logs.AssertTransaction(rec, "missing")
+
+	if len(rec.errors) != 4 {
+		t.Fatalf("got %d failures, want 4: %v", len(rec.errors), rec.errors)
+	}
+	if !strings.Contains(rec.errors[0], "hello") {
+		t.Errorf("failure does not list logged entries: %s", rec.errors[0])
+	}
+}
+
+func TestTransactionFilter(t *testing.T) {
+	appLogger, logs := logtest.New(t)
+
+	handler := appLogger.StandardHTTPMiddleware(logger.MiddlewareConfig{ServiceName: "payment"})(
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			appLogger.InfoCtx(r.Context(), "charging")
+			w.WriteHeader(http.StatusPaymentRequired)
+		}))
+	for _, id := range []string{"req-1", "req-2"} {
+		req := httptest.NewRequest("POST", "/pay", nil)
+		req.Header.Set("X-Request-ID", id)
+		handler.ServeHTTP(httptest.NewRecorder(), req)
+	}
+
+	entries := logs.AssertTransaction(t, "req-1")
+	if got := entries.Messages(); len(got) != 3 || got[1] != "charging" {
+		t.Fatalf("transaction req-1 = %v", got)
+	}
+	stop := entries.Flag(logger.FlagStop)
+	if len(stop) != 1 || stop[0].Level() != logger.LevelError {
+		t.Fatalf("STOP = %v", stop)
+	}
+	if len(stop.Field("status", 402)) != 1 {
+		t.Errorf("STOP has no status=402 field: %v", stop[0].Fields)
+	}
+}
+
+func TestTakeAllAndReset(t *testing.T) {
+	appLogger, logs := logtest.New(t)
+
+	appLogger.Info("first")
+	if got := logs.TakeAll().Messages(); len(got) != 1 || got[0] != "first" {
+		t.Fatalf("TakeAll = %v", got)
+	}
+	appLogger.Info("second")
+	if got := logs.All().Messages(); len(got) != 1 || got[0] != "second" {
+		t.Fatalf("All after TakeAll = %v", got)
+	}
+	logs.Reset()
+	if logs.Len() != 0 {
+		t.Fatalf("Len after Reset = %d", logs.Len())
+	}
+}
+
+func TestNewWithConfig(t *testing.T) {
+	appLogger, logs := logtest.NewWithConfig(t, &logger.LoggerConfig{
+		MinLevel: logger.LevelWarning,
+		Redactor: logger.NewRedactor(logger.RedactConfig{}),
+	})
+
+	appLogger.Info("ignored")
+	appLogger.Warning("login password=hunter2")
+
+	if logs.Len() != 1 {
+		t.Fatalf("Len = %d, want 1", logs.Len())
+	}
+	logs.AssertLogged(t, logger.LevelWarning, "password=[REDACTED]")
+}
+
+func TestDeterministicClockAndIDs(t *testing.T) {
+	run := func() []string {
+		appLogger, logs := logtest.NewWithConfig(t, &logger.LoggerConfig{
+			Clock:       logtest.NewStepClock(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), time.Millisecond),
+			IDGenerator: logtest.NewSequenceIDs(),
+			Host:        logger.HostConfig{Hostname: "test-host", IP: "10.0.0.1"},
+		})
+		ctx := appLogger.Start(context.Background(), logger.StartConfig{ServiceName: "svc"})
+		appLogger.InfoCtx(ctx, "inside")
+		spanCtx, done := appLogger.Span(ctx, "db.query")
+		appLogger.InfoCtx(spanCtx, "query")
+		done(errors.New("no rows"))
+		appLogger.Stop(ctx, "INFO", "done", "")
+
+		var lines []string
+		for _, e := range logs.All() {
+			lines = append(lines, logger.FormatJSON.Format(&e.LogEntry))
+		}
+		return lines
+	}
+
+	first, second := run(), run()
+	if strings.Join(first, "\n") != strings.Join(second, "\n") {
+		t.Fatalf("output differs between runs:\n%s\n---\n%s", strings.Join(first, "\n"), strings.Join(second, "\n"))
+	}
+	if !strings.Contains(first[0], `"timestamp":"2024-01-01T00:00:00.001Z"`) {
+		t.Errorf("first entry = %s", first[0])
+	}
+	if !strings.Contains(first[0], `"transaction_id":"00000000-0000-7000-8000-000000000001"`) {
+		t.Errorf("first entry = %s", first[0])
+	}
+	if !strings.Contains(first[2], `"span_id":"0000000000000001"`) {
+		t.Errorf("span START = %s", first[2])
+	}
+}
+
+func TestStepClock(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	clock := logtest.NewStepClock(start, time.Second)
+	for i := 0; i < 3; i++ {
+		if got, want := clock.Now(), start.Add(time.Duration(i)*time.Second); !got.Equal(want) {
+			t.Fatalf("Now #%d = %v, want %v", i, got, want)
+		}
+	}
+}
+
+func TestSequenceIDs(t *testing.T) {
+	ids := logtest.NewSequenceIDs()
+	if got := ids.NewUUID(); got != "00000000-0000-7000-8000-000000000001" {
+		t.Errorf("NewUUID = %s", got)
+	}
+	if got := ids.NewUUID(); got != "00000000-0000-7000-8000-000000000002" {
+		t.Errorf("NewUUID = %s", got)
+	}
+	if got := ids.NewTraceID(); got != "00000000000000000000000000000001" {
+		t.Errorf("NewTraceID = %s", got)
+	}
+	if got := ids.NewSpanID(); got != "0000000000000001" {
+		t.Errorf("NewSpanID = %s", got)
+	}
+}
@@ -3,7 +3,7 @@ package logger
 import (
 	"context"
 	"net/http"
-	"time"
+	"runtime/debug"
 )
 
 // HTTPRequestInfo contains information extracted from HTTP request
@@ -18,7 +18,8 @@ type HTTPRequestInfo interface {
 
 // StandardHTTPRequest implements HTTPRequestInfo for standard net/http
 type StandardHTTPRequest struct {
-	req *http.Request
+	req  *http.Request
+	body string // Request body yang di-capture oleh middleware
 }
 
 func (r *StandardHTTPRequest) Method() string {
@@ -36,8 +37,8 @@ func (r *StandardHTTPRequest) Path() string {
 }
 
 func (r *StandardHTTPRequest) Body() string {
-	// Body reading should be handled by framework middleware
-	return ""
+	// Body di-capture oleh StandardHTTPMiddleware (lihat MiddlewareConfig.CaptureRequestBody)
+	return r.body
 }
 
 func (r *StandardHTTPRequest) Header(key string) string {
@@ -87,21 +88,19 @@ func (l *Logger) StartFromHTTPRequestInfo(reqInfo HTTPRequestInfo, config StartC
 		ctx = WithServiceName(ctx, config.ServiceName)
 	}
 
-	// Generate or use existing UUID
-	if config.TransactionID == "" {
-		ctx = WithNewUUID(ctx)
-	} else {
-		ctx = WithUUID(ctx, config.TransactionID)
-		ctx = WithTransactionID(ctx, config.TransactionID)
+	// Correlation IDs dari header upstream (traceparent, B3, X-Request-ID, X-Correlation-ID),
+	// atau generate traceparent baru jika tidak ada. Config tetap bisa override.
+	corr := extractCorrelation(reqInfo.Header, config.Propagation, l.ids)
+	if config.TransactionID != "" {
+		corr.TransactionID = config.TransactionID
 	}
-
-	// Set trace ID if provided
 	if config.TraceID != "" {
-		ctx = WithTraceID(ctx, config.TraceID)
+		corr.TraceID = config.TraceID
 	}
+	ctx = WithCorrelation(ctx, corr)
 
 	// Set start time for execution time tracking
-	ctx = WithStartTime(ctx, time.Now())
+	ctx = WithStartTime(ctx, l.now())
 
 	// Set default level if not provided
 	level := config.Level
@@ -131,6 +130,53 @@ func (l *Logger) StartFromHTTPRequestInfo(reqInfo HTTPRequestInfo, config StartC
 type MiddlewareConfig struct {
 	ServiceName string   // Nama service
 	SkipPaths   []string // Path yang di-skip dari logging
+
+	// Propagation adalah urutan prioritas header korelasi yang dibaca (default: DefaultPropagation)
+	Propagation []PropagationSource
+	// SkipResponseHeaders menonaktifkan echo traceparent / X-Request-ID ke response
+	SkipResponseHeaders bool
+
+	// Body capture
+	CaptureRequestBody   bool     // Tulis request body di entry START (default: tidak)
+	SkipResponseBody     bool     // Jangan tulis response body di entry STOP (default: ditulis)
+	MaxBodyBytes         int      // Batas body yang ditulis (default: 4096), sisanya diberi tanda "...(truncated)"
+	BodyContentTypes     []string // Allow list content type, kosong berarti semua kecuali SkipBodyContentTypes
+	SkipBodyContentTypes []string // Deny list content type (default: DefaultSkipBodyContentTypes)
+	SkipBodyPaths        []string // Path yang body-nya tidak ditulis (request maupun response)
+
+	// Redactor me-mask data sensitif di request dan response body (nil = tanpa redaction)
+	Redactor *Redactor
+
+	// Panic recovery: panic di handler ditulis sebagai ERROR STOP dengan stack trace
+	RecoverPanic     bool   // Aktifkan recovery (default: panic diteruskan tanpa entry STOP)
+	PanicBody        string // Body response 500 (default: "Internal Server Error")
+	PanicContentType string // Content-Type response 500 (default: "text/plain; charset=utf-8")
+	Repanic          bool   // Panic lagi setelah log ditulis (misalnya agar ditangani recovery lain)
+
+	// AccessLogger menulis satu baris access log per request (Common, Combined atau template custom)
+	// di samping entry START/STOP, biasanya dari NewAccessLogger (nil = nonaktif)
+	AccessLogger *Logger
+}
+
+// ShouldSkip reports whether path is listed in SkipPaths
+func (c MiddlewareConfig) ShouldSkip(path string) bool {
+	for _, skipPath := range c.SkipPaths {
+		if path == skipPath {
+			return true
+		}
+	}
+	return false
+}
+
+// LevelForStatus returns the STOP level for an HTTP status: ERROR untuk 4xx/5xx,
+// WARNING untuk 3xx dan SUCCESS selain itu
+func LevelForStatus(status int) string {
+	if status >= 400 {
+		return "ERROR"
+	} else if status >= 300 {
+		return "WARNING"
+	}
+	return "SUCCESS"
 }
 
 // StandardHTTPMiddleware untuk net/http standard library
@@ -138,60 +184,78 @@ func (l *Logger) StandardHTTPMiddleware(config MiddlewareConfig) func(http.Handl
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			// Skip paths jika ada
-			for _, skipPath := range config.SkipPaths {
-				if r.URL.Path == skipPath {
-					next.ServeHTTP(w, r)
-					return
-				}
+			if config.ShouldSkip(r.URL.Path) {
+				next.ServeHTTP(w, r)
+				return
 			}
 
-			// Create request info
-			reqInfo := &StandardHTTPRequest{req: r}
+			// Create request info, request body di-peek tanpa mengkonsumsi r.Body
+			body := config.ReadRequestBody(r)
+			reqInfo := &StandardHTTPRequest{req: r, body: body}
 
 			// Start logging
 			startConfig := StartConfig{
 				ServiceName: config.ServiceName,
+				Propagation: config.Propagation,
 				// Method dan Endpoint otomatis dari request
 			}
 			ctx := l.StartFromHTTPRequestInfo(reqInfo, startConfig)
 
-			// Wrap response writer untuk capture status code
-			wrapped := &responseWriter{ResponseWriter: w, statusCode: http.StatusOK}
+			// Echo correlation IDs agar client / gateway bisa mencocokkan log
+			if !config.SkipResponseHeaders {
+				SetCorrelationHeaders(w.Header(), CorrelationFromContext(ctx))
+			}
+
+			// Wrap response writer untuk capture status, bytes, TTFB dan body.
+			// Flusher, Hijacker, Pusher dan ReaderFrom dari writer asli tetap tersedia.
+			capture := config.NewResponseCapture(r.URL.Path)
+			wrapped := wrapResponseWriter(w, capture, l.clock)
+
+			// Recover panic dari handler dan tulis ERROR STOP
+			if config.RecoverPanic {
+				defer func() {
+					if rec := recover(); rec != nil {
+						stack := debug.Stack()
+						if rec != http.ErrAbortHandler && !wrapped.Written() {
+							config.WritePanicResponse(wrapped)
+						}
+						l.StopPanic(WithFields(ctx, responseFields(wrapped)...), rec, stack, capture.String())
+						config.logAccess(ctx, r, wrapped.Status(), wrapped.BytesWritten())
+						if config.ShouldRepanic(rec) {
+							panic(rec)
+						}
+					}
+				}()
+			}
 
 			// Process request
 			next.ServeHTTP(wrapped, r.WithContext(ctx))
 
 			// Stop logging
-			level := "SUCCESS"
-			if wrapped.statusCode >= 400 {
-				level = "ERROR"
-			} else if wrapped.statusCode >= 300 {
-				level = "WARNING"
-			}
+			level := LevelForStatus(wrapped.Status())
 
-			body := ""
-			if wrapped.body != nil {
-				body = string(wrapped.body)
-			}
-
-			l.Stop(ctx, level, "Request completed", body)
+			l.Stop(WithFields(ctx, responseFields(wrapped)...), level, "Request completed", capture.String())
+			config.logAccess(ctx, r, wrapped.Status(), wrapped.BytesWritten())
 		})
 	}
 }
 
-// responseWriter wraps http.ResponseWriter untuk capture status code dan body
-type responseWriter struct {
-	http.ResponseWriter
-	statusCode int
-	body       []byte
-}
-
-func (rw *responseWriter) WriteHeader(code int) {
-	rw.statusCode = code
-	rw.ResponseWriter.WriteHeader(code)
+// logAccess writes the access log line of r jika AccessLogger di-set
+func (c MiddlewareConfig) logAccess(ctx context.Context, r *http.Request, status int, bytes int64) {
+	if c.AccessLogger != nil {
+		c.AccessLogger.LogAccess(ctx, NewAccessLogInfo(r, status, bytes))
+	}
 }
 
-func (rw *responseWriter) Write(b []byte) (int, error) {
-	rw.body = b
-	return rw.ResponseWriter.Write(b)
+// responseFields returns the STOP fields of a wrapped response: status, bytes, TTFB dan hijack
+func responseFields(w ResponseWriter) []Field {
+	fields := []Field{
+		Int("status", w.Status()),
+		Int64("bytes", w.BytesWritten()),
+		Duration("ttfb", w.TTFB()),
+	}
+	if w.Hijacked() {
+		fields = append(fields, Bool("hijacked", true))
+	}
+	return fields
 }
@@ -0,0 +1,115 @@
+package logger
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// LogMode selects how entries are written to the sinks
+type LogMode string
+
+const (
+	// ModeAsync queues entries to a worker goroutine (default): caller tidak pernah menunggu I/O
+	ModeAsync LogMode = "async"
+	// ModeSync writes entries in the calling goroutine sebelum method log kembali, sehingga urutan
+	// entry selalu sama dengan urutan pemanggilan dan output lain (misalnya fmt.Println)
+	ModeSync LogMode = "sync"
+)
+
+// Clock provides the time of log entries and durations (default: time.Now)
+type Clock interface {
+	Now() time.Time
+}
+
+// IDGenerator generates the IDs written to log entries. Implementasi deterministik
+// (lihat logtest.NewSequenceIDs) membuat output byte-identical untuk golden-file test.
+type IDGenerator interface {
+	NewUUID() string    // UUID entry dan transaction ID (default: UUID v7)
+	NewTraceID() string // Trace ID W3C, 32 hex (default: crypto/rand)
+	NewSpanID() string  // Span ID W3C, 16 hex (default: crypto/rand)
+}
+
+// systemClock is the default Clock
+type systemClock struct{}
+
+func (systemClock) Now() time.Time {
+	return time.Now()
+}
+
+// randomIDs is the default IDGenerator
+type randomIDs struct{}
+
+func (randomIDs) NewUUID() string {
+	return generateUUID()
+}
+
+func (randomIDs) NewTraceID() string {
+	return randomHex(16)
+}
+
+func (randomIDs) NewSpanID() string {
+	return randomHex(8)
+}
+
+// parseLogMode validates a mode, returning ModeAsync if empty
+func parseLogMode(mode LogMode) (LogMode, error) {
+	switch mode {
+	case "":
+		return ModeAsync, nil
+	case ModeAsync, ModeSync:
+		return mode, nil
+	}
+	return "", fmt.Errorf("unsupported log mode '%s'", mode)
+}
+
+// now returns the current time of the logger's Clock
+func (l *Logger) now() time.Time {
+	return l.clock.Now()
+}
+
+// uuidFromContext extracts UUID from context or generates a new one with the IDGenerator
+func (l *Logger) uuidFromContext(ctx context.Context) string {
+	if ctx != nil {
+		if uuid, ok := ctx.Value(UUIDKey).(string); ok && uuid != "" {
+			return uuid
+		}
+	}
+	return l.ids.NewUUID()
+}
+
+// writeSync writes msg in the calling goroutine (ModeSync). Mutex menggantikan worker
+// sehingga sink tetap hanya diakses oleh satu goroutine pada satu waktu.
+func (l *Logger) writeSync(msg *logMessage) {
+	l.syncMu.Lock()
+	defer l.syncMu.Unlock()
+
+	select {
+	case <-l.closed:
+		// Sink sudah ditutup oleh Close
+		if msg.flushed != nil {
+			close(msg.flushed)
+		} else {
+			l.dropped.Add(1)
+		}
+		return
+	default:
+	}
+	l.writeLog(msg)
+}
+
+// enqueueReport queues a summary line of reportDropped or reportSampled tanpa menunggu;
+// false jika channel masih penuh
+func (l *Logger) enqueueReport(msg *logMessage) bool {
+	msg.time = l.now()
+	if l.sync {
+		l.writeSync(msg)
+		return true
+	}
+	select {
+	case l.logChan <- msg:
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,83 @@
+package logger_test
+
+import (
+	"bytes"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/funxdofficial/golang-module-syslog/logger"
+	"github.com/funxdofficial/golang-module-syslog/logger/logtest"
+)
+
+// lockedBuffer is a bytes.Buffer that can be shared between the caller and the worker
+type lockedBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *lockedBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Write(p)
+}
+
+func (b *lockedBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.String()
+}
+
+func TestModeSyncWritesBeforeReturn(t *testing.T) {
+	out := &lockedBuffer{}
+	appLogger, err := logger.StartLogger(&logger.LoggerConfig{
+		Mode:        logger.ModeSync,
+		Sinks:       []logger.SinkConfig{{Sink: logger.NewWriterSink(out, logger.FormatLogfmt)}},
+		Clock:       logtest.NewStepClock(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), time.Second),
+		IDGenerator: logtest.NewSequenceIDs(),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer appLogger.Close()
+
+	// Tanpa Flush: entry sudah ada di output saat method log kembali, berurutan dengan output lain
+	appLogger.Info("first")
+	out.Write([]byte("raw line\n"))
+	appLogger.Warning("second")
+
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	if len(lines) != 3 {
+		t.Fatalf("got %d lines: %q", len(lines), lines)
+	}
+	if !strings.Contains(lines[0], "message=first") || lines[1] != "raw line" || !strings.Contains(lines[2], "message=second") {
+		t.Errorf("lines = %q", lines)
+	}
+	if !strings.HasPrefix(lines[0], "timestamp=2024-01-01T00:00:00.000Z level=INFO message=first uuid=00000000-0000-7000-8000-000000000001") {
+		t.Errorf("first line = %s", lines[0])
+	}
+}
+
+func TestModeSyncConcurrentCallers(t *testing.T) {
+	appLogger, logs := logtest.NewWithConfig(t, &logger.LoggerConfig{Mode: logger.ModeSync})
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 50; j++ {
+				appLogger.Info("concurrent")
+			}
+		}()
+	}
+	wg.Wait()
+	logs.AssertCount(t, logger.LevelInfo, 400)
+}
+
+func TestStartLoggerRejectsUnknownMode(t *testing.T) {
+	if _, err := logger.StartLogger(&logger.LoggerConfig{Mode: "batch", Sinks: []logger.SinkConfig{{Sink: logger.NewWriterSink(&bytes.Buffer{}, nil)}}}); err == nil {
+		t.Fatal("expected error for unknown mode")
+	}
+}
@@ -0,0 +1,489 @@
+package otellog
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/funxdofficial/golang-module-syslog/logger"
+	collogspb "go.opentelemetry.io/proto/otlp/collector/logs/v1"
+	commonpb "go.opentelemetry.io/proto/otlp/common/v1"
+	logspb "go.opentelemetry.io/proto/otlp/logs/v1"
+	resourcepb "go.opentelemetry.io/proto/otlp/resource/v1"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/protobuf/proto"
+)
+
+// Protocol is the OTLP transport of the exporter
+type Protocol string
+
+const (
+	ProtocolHTTP Protocol = "http/protobuf" // POST {Endpoint}/v1/logs (default port 4318)
+	ProtocolGRPC Protocol = "grpc"          // LogsService/Export (default port 4317)
+)
+
+// scopeName is the instrumentation scope of exported log records
+const scopeName = "github.com/funxdofficial/golang-module-syslog/logger"
+
+// ExporterConfig untuk export entry sebagai OTLP log record ke collector
+type ExporterConfig struct {
+	Protocol Protocol // Default: ProtocolHTTP
+
+	// Endpoint collector. HTTP: base URL seperti "http://localhost:4318" (path /v1/logs ditambahkan
+	// jika tidak ada path). gRPC: host:port seperti "localhost:4317".
+	Endpoint string
+
+	Headers   map[string]string // Header HTTP / metadata gRPC tambahan, misalnya API key
+	Insecure  bool              // gRPC tanpa TLS (HTTP mengikuti scheme Endpoint)
+	TLSConfig *tls.Config       // TLS untuk gRPC dan HTTPS (nil = default sistem)
+	Timeout   time.Duration     // Timeout per export (default: 10s)
+
+	BatchSize     int           // Export jika jumlah record mencapai N (default: 512)
+	FlushInterval time.Duration // Export record yang tertunda setiap interval (default: 5s, negatif = hanya saat batch penuh / Flush)
+	QueueSize     int           // Jumlah record maksimal di queue; jika penuh record di-drop (default: 2048)
+
+	// Resource dibangun sekali saat NewExporter dari config, bukan dari entry
+	ServiceName string            // Resource service.name (default: "unknown_service:" + nama executable)
+	Resource    map[string]string // Resource attributes tambahan, misalnya deployment.environment
+	Host        logger.HostConfig // host.name, host.ip dan k8s.* (default: dideteksi seperti logger)
+
+	HTTPClient *http.Client // Client untuk ProtocolHTTP (default: client dengan TLSConfig)
+}
+
+// Exporter is a logger.Sink that exports entries as OTLP log records.
+// Write hanya memasukkan record ke queue; goroutine exporter mengirim batch saat batch penuh,
+// setiap FlushInterval, serta saat Flush dan Close, sehingga collector yang lambat tidak
+// memblok worker logger. Jika queue penuh record di-drop dan dihitung (lihat Dropped).
+//
+//	exporter, err := otellog.NewExporter(otellog.ExporterConfig{Endpoint: "http://otel-collector:4318"})
+//	appLogger, err := logger.StartLogger(&logger.LoggerConfig{
+//		Type:        logger.LogTypeConsole,
+//		SpanContext: otellog.SpanContext,
+//		Sinks:       []logger.SinkConfig{{Sink: exporter}},
+//	})
+type Exporter struct {
+	config  ExporterConfig
+	send    func(ctx context.Context, req *collogspb.ExportLogsServiceRequest) error
+	conn    *grpc.ClientConn
+	headers metadata.MD
+
+	queue   chan *logspb.LogRecord
+	flushes chan chan error
+	dropped atomic.Uint64
+
+	resource *resourcepb.Resource
+
+	closeOnce sync.Once
+	closed    chan struct{}
+	done      chan struct{}
+	closeErr  error
+}
+
+// NewExporter creates an OTLP exporter sink
+func NewExporter(config ExporterConfig) (*Exporter, error) {
+	if config.Endpoint == "" {
+		return nil, fmt.Errorf("otlp endpoint is required")
+	}
+	if config.Timeout <= 0 {
+		config.Timeout = 10 * time.Second
+	}
+	if config.BatchSize <= 0 {
+		config.BatchSize = 512
+	}
+	if config.FlushInterval == 0 {
+		config.FlushInterval = 5 * time.Second
+	}
+	if config.QueueSize <= 0 {
+		config.QueueSize = 2048
+	}
+
+	host, err := logger.DetectHostIdentity(config.Host)
+	if err != nil {
+		return nil, err
+	}
+
+	e := &Exporter{
+		config:   config,
+		resource: newResource(config, host),
+		queue:    make(chan *logspb.LogRecord, config.QueueSize),
+		flushes:  make(chan chan error),
+		closed:   make(chan struct{}),
+		done:     make(chan struct{}),
+	}
+	switch config.Protocol {
+	case "", ProtocolHTTP:
+		if err := e.initHTTP(); err != nil {
+			return nil, err
+		}
+	case ProtocolGRPC:
+		if err := e.initGRPC(); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, fmt.Errorf("unsupported otlp protocol '%s'", config.Protocol)
+	}
+
+	go e.run()
+	return e, nil
+}
+
+// initHTTP sets up the OTLP/HTTP protobuf transport
+func (e *Exporter) initHTTP() error {
+	url := e.config.Endpoint
+	if !strings.Contains(url, "://") {
+		url = "https://" + url
+	}
+	if scheme := strings.Index(url, "://") + 3; !strings.Contains(url[scheme:], "/") {
+		url += "/v1/logs"
+	}
+	client := e.config.HTTPClient
+	if client == nil {
+		client = &http.Client{Transport: &http.Transport{
+			Proxy:           http.ProxyFromEnvironment,
+			TLSClientConfig: e.config.TLSConfig,
+		}}
+	}
+
+	e.send = func(ctx context.Context, req *collogspb.ExportLogsServiceRequest) error {
+		body, err := proto.Marshal(req)
+		if err != nil {
+			return err
+		}
+		httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+		if err != nil {
+			return err
+		}
+		httpReq.Header.Set("Content-Type", "application/x-protobuf")
+		for key, value := range e.config.Headers {
+			httpReq.Header.Set(key, value)
+		}
+
+		resp, err := client.Do(httpReq)
+		if err != nil {
+			return err
+		}
+		defer resp.Body.Close()
+		respBody, _ := io.ReadAll(io.LimitReader(resp.Body, 64<<10))
+		if resp.StatusCode/100 != 2 {
+			return fmt.Errorf("otlp export failed: %s", resp.Status)
+		}
+
+		var exportResp collogspb.ExportLogsServiceResponse
+		if err := proto.Unmarshal(respBody, &exportResp); err == nil {
+			return partialSuccessError(exportResp.GetPartialSuccess())
+		}
+		return nil
+	}
+	return nil
+}
+
+// initGRPC sets up the OTLP/gRPC transport
+func (e *Exporter) initGRPC() error {
+	creds := credentials.NewTLS(e.config.TLSConfig)
+	if e.config.Insecure {
+		creds = insecure.NewCredentials()
+	}
+	conn, err := grpc.NewClient(e.config.Endpoint, grpc.WithTransportCredentials(creds))
+	if err != nil {
+		return fmt.Errorf("failed to create otlp grpc client: %w", err)
+	}
+	e.conn = conn
+	e.headers = metadata.New(e.config.Headers)
+	client := collogspb.NewLogsServiceClient(conn)
+
+	e.send = func(ctx context.Context, req *collogspb.ExportLogsServiceRequest) error {
+		if len(e.headers) > 0 {
+			ctx = metadata.NewOutgoingContext(ctx, e.headers)
+		}
+		resp, err := client.Export(ctx, req)
+		if err != nil {
+			return err
+		}
+		return partialSuccessError(resp.GetPartialSuccess())
+	}
+	return nil
+}
+
+// partialSuccessError returns an error if the collector rejected some records
+func partialSuccessError(p *collogspb.ExportLogsPartialSuccess) error {
+	if p == nil || (p.GetRejectedLogRecords() == 0 && p.GetErrorMessage() == "") {
+		return nil
+	}
+	return fmt.Errorf("otlp collector rejected %d log records: %s", p.GetRejectedLogRecords(), p.GetErrorMessage())
+}
+
+// Write adds the entry to the queue. Write tidak pernah memblok: jika queue penuh
+// record di-drop dan dihitung di Dropped.
+func (e *Exporter) Write(entry *logger.LogEntry) error {
+	select {
+	case <-e.closed:
+		e.dropped.Add(1)
+		return nil
+	default:
+	}
+
+	select {
+	case e.queue <- newLogRecord(entry):
+	default:
+		e.dropped.Add(1)
+	}
+	return nil
+}
+
+// Flush exports the records queued before the call and waits for the result
+func (e *Exporter) Flush() error {
+	result := make(chan error, 1)
+	select {
+	case e.flushes <- result:
+		return <-result
+	case <-e.done:
+		return nil
+	}
+}
+
+// Close exports the pending records and closes the connection
+func (e *Exporter) Close() error {
+	e.closeOnce.Do(func() {
+		close(e.closed)
+		<-e.done
+		if e.conn != nil {
+			if err := e.conn.Close(); e.closeErr == nil {
+				e.closeErr = err
+			}
+		}
+	})
+	return e.closeErr
+}
+
+// Dropped returns the number of records dropped because the queue was full or the exporter was closed
+func (e *Exporter) Dropped() uint64 {
+	return e.dropped.Load()
+}
+
+// run is the exporter goroutine: mengumpulkan record dari queue dan mengirim batch ke collector
+func (e *Exporter) run() {
+	defer close(e.done)
+
+	var tick <-chan time.Time
+	if e.config.FlushInterval > 0 {
+		ticker := time.NewTicker(e.config.FlushInterval)
+		defer ticker.Stop()
+		tick = ticker.C
+	}
+
+	var batch []*logspb.LogRecord
+	var reported uint64
+	export := func() error {
+		err := e.export(batch)
+		batch = nil
+		if dropped := e.dropped.Load(); dropped != reported {
+			fmt.Fprintf(os.Stderr, "[LOGGER ERROR] OTLP exporter dropped %d log records, queue full (total: %d)\n",
+				dropped-reported, dropped)
+			reported = dropped
+		}
+		return err
+	}
+	// exportQueued exports the batch and the records already in the queue. Record yang masuk
+	// setelahnya tidak ditunggu, sehingga Flush tetap selesai walaupun Write terus berjalan.
+	exportQueued := func() error {
+		var err error
+		for n := len(e.queue); n > 0; n-- {
+			batch = append(batch, <-e.queue)
+			if len(batch) >= e.config.BatchSize {
+				if exportErr := export(); err == nil {
+					err = exportErr
+				}
+			}
+		}
+		if exportErr := export(); err == nil {
+			err = exportErr
+		}
+		return err
+	}
+
+	for {
+		select {
+		case record := <-e.queue:
+			batch = append(batch, record)
+			if len(batch) >= e.config.BatchSize {
+				logExportError(export())
+			}
+		case <-tick:
+			logExportError(export())
+		case result := <-e.flushes:
+			result <- exportQueued()
+		case <-e.closed:
+			e.closeErr = exportQueued()
+			return
+		}
+	}
+}
+
+// export sends batch to the collector; record yang gagal dikirim di-drop
+// sehingga memory tetap terbatas saat collector tidak tersedia
+func (e *Exporter) export(batch []*logspb.LogRecord) error {
+	if len(batch) == 0 {
+		return nil
+	}
+	req := &collogspb.ExportLogsServiceRequest{
+		ResourceLogs: []*logspb.ResourceLogs{{
+			Resource: e.resource,
+			ScopeLogs: []*logspb.ScopeLogs{{
+				Scope:      &commonpb.InstrumentationScope{Name: scopeName},
+				LogRecords: batch,
+			}},
+		}},
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), e.config.Timeout)
+	defer cancel()
+	return e.send(ctx, req)
+}
+
+// logExportError writes an export error of the background exporter to stderr
+func logExportError(err error) {
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "[LOGGER ERROR] Failed to export logs: %v\n", err)
+	}
+}
+
+// newResource builds the resource from the config and the host identity
+func newResource(config ExporterConfig, host logger.HostIdentity) *resourcepb.Resource {
+	serviceName := config.ServiceName
+	if serviceName == "" {
+		// Default service.name dari OpenTelemetry semantic conventions
+		serviceName = "unknown_service:" + filepath.Base(os.Args[0])
+	}
+
+	var attrs []*commonpb.KeyValue
+	attrs = appendString(attrs, "service.name", serviceName)
+	attrs = appendString(attrs, "host.name", host.Hostname)
+	if host.IP != "unknown" {
+		attrs = appendString(attrs, "host.ip", host.IP)
+	}
+	attrs = appendString(attrs, "k8s.pod.name", host.PodName)
+	attrs = appendString(attrs, "k8s.namespace.name", host.Namespace)
+	attrs = appendString(attrs, "k8s.node.name", host.NodeName)
+
+	keys := make([]string, 0, len(config.Resource))
+	for key := range config.Resource {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		attrs = appendString(attrs, key, config.Resource[key])
+	}
+	return &resourcepb.Resource{Attributes: attrs}
+}
+
+// newLogRecord converts an entry to an OTLP log record
+func newLogRecord(entry *logger.LogEntry) *logspb.LogRecord {
+	record := &logspb.LogRecord{
+		TimeUnixNano:         uint64(entry.Time.UnixNano()),
+		ObservedTimeUnixNano: uint64(time.Now().UnixNano()),
+		SeverityNumber:       severityNumber(entry.LogLevel),
+		SeverityText:         entry.LogLevel,
+		Body:                 stringValue(entry.Message),
+	}
+	if traceID, err := hex.DecodeString(entry.TraceID); err == nil && len(traceID) == 16 {
+		record.TraceId = traceID
+		if spanID, err := hex.DecodeString(entry.SpanID); err == nil && len(spanID) == 8 {
+			record.SpanId = spanID
+		}
+		if flags, err := hex.DecodeString(entry.TraceFlags); err == nil && len(flags) == 1 {
+			record.Flags = uint32(flags[0])
+		}
+	}
+
+	var attrs []*commonpb.KeyValue
+	attrs = appendString(attrs, "log.flag", string(entry.Flag))
+	attrs = appendString(attrs, "log.uuid", entry.UUID)
+	attrs = appendString(attrs, "transaction_id", entry.TransactionID)
+	if record.TraceId == nil {
+		// Trace ID non-W3C (misalnya UUID dari Start) tetap dikirim sebagai attribute
+		attrs = appendString(attrs, "trace_id", entry.TraceID)
+	}
+	if entry.TransactionID != "" {
+		attrs = appendString(attrs, "service_name", entry.ServiceName)
+		attrs = appendString(attrs, "http.route", entry.Endpoint)
+		attrs = appendString(attrs, "http.request.method", entry.MethodType)
+		attrs = appendString(attrs, "execution_time", entry.ExecutionTime)
+		attrs = appendString(attrs, "body", entry.Body)
+	}
+	attrs = appendString(attrs, "code.file.path", entry.File)
+	if entry.Line > 0 {
+		attrs = append(attrs, &commonpb.KeyValue{Key: "code.line.number", Value: intValue(int64(entry.Line))})
+	}
+	attrs = appendString(attrs, "code.function.name", entry.Function)
+	for _, field := range entry.Fields {
+		attrs = append(attrs, &commonpb.KeyValue{Key: field.Key, Value: fieldValue(field)})
+	}
+	record.Attributes = attrs
+	return record
+}
+
+// severityNumber maps a logger level to the OTLP severity number
+func severityNumber(level string) logspb.SeverityNumber {
+	switch level {
+	case "TRACE":
+		return logspb.SeverityNumber_SEVERITY_NUMBER_TRACE
+	case "DEBUG":
+		return logspb.SeverityNumber_SEVERITY_NUMBER_DEBUG
+	case "INFO":
+		return logspb.SeverityNumber_SEVERITY_NUMBER_INFO
+	case "SUCCESS":
+		return logspb.SeverityNumber_SEVERITY_NUMBER_INFO2
+	case "WARNING":
+		return logspb.SeverityNumber_SEVERITY_NUMBER_WARN
+	case "ERROR":
+		return logspb.SeverityNumber_SEVERITY_NUMBER_ERROR
+	case "FATAL":
+		return logspb.SeverityNumber_SEVERITY_NUMBER_FATAL
+	case "PANIC":
+		return logspb.SeverityNumber_SEVERITY_NUMBER_FATAL2
+	}
+	return logspb.SeverityNumber_SEVERITY_NUMBER_UNSPECIFIED
+}
+
+// fieldValue converts a structured field to an OTLP value
+func fieldValue(field logger.Field) *commonpb.AnyValue {
+	switch v := field.Value.(type) {
+	case int64:
+		return intValue(v)
+	case bool:
+		return &commonpb.AnyValue{Value: &commonpb.AnyValue_BoolValue{BoolValue: v}}
+	case float64:
+		return &commonpb.AnyValue{Value: &commonpb.AnyValue_DoubleValue{DoubleValue: v}}
+	}
+	return stringValue(field.ValueString())
+}
+
+// appendString appends a string attribute, kecuali nilainya kosong
+func appendString(attrs []*commonpb.KeyValue, key, value string) []*commonpb.KeyValue {
+	if value == "" {
+		return attrs
+	}
+	return append(attrs, &commonpb.KeyValue{Key: key, Value: stringValue(value)})
+}
+
+func stringValue(s string) *commonpb.AnyValue {
+	return &commonpb.AnyValue{Value: &commonpb.AnyValue_StringValue{StringValue: s}}
+}
+
+func intValue(i int64) *commonpb.AnyValue {
+	return &commonpb.AnyValue{Value: &commonpb.AnyValue_IntValue{IntValue: i}}
+}
@@ -0,0 +1,423 @@
+package otellog_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/funxdofficial/golang-module-syslog/logger"
+	"github.com/funxdofficial/golang-module-syslog/logger/logtest"
+	"github.com/funxdofficial/golang-module-syslog/logger/otellog"
+	"go.opentelemetry.io/otel/trace"
+	collogspb "go.opentelemetry.io/proto/otlp/collector/logs/v1"
+	commonpb "go.opentelemetry.io/proto/otlp/common/v1"
+	logspb "go.opentelemetry.io/proto/otlp/logs/v1"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	testTraceID = "4bf92f3577b34da6a3ce929d0e0e4736"
+	testSpanID  = "00f067aa0ba902b7"
+)
+
+// collector records the export requests received by a stub collector
+type collector struct {
+	mu       sync.Mutex
+	requests []*collogspb.ExportLogsServiceRequest
+	headers  []string // Nilai header / metadata x-api-key per request
+	partial  *collogspb.ExportLogsPartialSuccess
+	block    chan struct{} // Jika tidak nil, export menunggu sampai channel ditutup
+}
+
+func (c *collector) export(req *collogspb.ExportLogsServiceRequest, apiKey string) *collogspb.ExportLogsServiceResponse {
+	if c.block != nil {
+		<-c.block
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.requests = append(c.requests, req)
+	c.headers = append(c.headers, apiKey)
+	return &collogspb.ExportLogsServiceResponse{PartialSuccess: c.partial}
+}
+
+// records returns all received log records
+func (c *collector) records() []*logspb.LogRecord {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	var records []*logspb.LogRecord
+	for _, req := range c.requests {
+		for _, rl := range req.GetResourceLogs() {
+			for _, sl := range rl.GetScopeLogs() {
+				records = append(records, sl.GetLogRecords()...)
+			}
+		}
+	}
+	return records
+}
+
+func (c *collector) requestCount() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return len(c.requests)
+}
+
+// newHTTPCollector starts an OTLP/HTTP protobuf stub collector
+func newHTTPCollector(t *testing.T, c *collector) string {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/logs" || r.Header.Get("Content-Type") != "application/x-protobuf" {
+			http.Error(w, "unexpected request", http.StatusBadRequest)
+			return
+		}
+		body, _ := io.ReadAll(r.Body)
+		var req collogspb.ExportLogsServiceRequest
+		if err := proto.Unmarshal(body, &req); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		resp, _ := proto.Marshal(c.export(&req, r.Header.Get("X-Api-Key")))
+		w.Header().Set("Content-Type", "application/x-protobuf")
+		w.Write(resp)
+	}))
+	t.Cleanup(server.Close)
+	return server.URL
+}
+
+// grpcCollector is an in-process LogsServiceServer
+type grpcCollector struct {
+	collogspb.UnimplementedLogsServiceServer
+	*collector
+}
+
+func (g grpcCollector) Export(ctx context.Context, req *collogspb.ExportLogsServiceRequest) (*collogspb.ExportLogsServiceResponse, error) {
+	var apiKey string
+	if md, ok := metadata.FromIncomingContext(ctx); ok && len(md.Get("x-api-key")) > 0 {
+		apiKey = md.Get("x-api-key")[0]
+	}
+	return g.export(req, apiKey), nil
+}
+
+// newGRPCCollector starts an OTLP/gRPC stub collector
+func newGRPCCollector(t *testing.T, c *collector) string {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	server := grpc.NewServer()
+	collogspb.RegisterLogsServiceServer(server, grpcCollector{collector: c})
+	go server.Serve(listener)
+	t.Cleanup(server.Stop)
+	return listener.Addr().String()
+}
+
+func TestExporterProtocols(t *testing.T) {
+	tests := []struct {
+		name   string
+		config func(t *testing.T, c *collector) otellog.ExporterConfig
+	}{
+		{"http/protobuf", func(t *testing.T, c *collector) otellog.ExporterConfig {
+			return otellog.ExporterConfig{Endpoint: newHTTPCollector(t, c)}
+		}},
+		{"grpc", func(t *testing.T, c *collector) otellog.ExporterConfig {
+			return otellog.ExporterConfig{Protocol: otellog.ProtocolGRPC, Endpoint: newGRPCCollector(t, c), Insecure: true}
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &collector{}
+			config := tt.config(t, c)
+			config.Headers = map[string]string{"x-api-key": "secret"}
+			config.Resource = map[string]string{"deployment.environment": "test"}
+			config.ServiceName = "payment-service"
+			config.Host = logger.HostConfig{Hostname: "test-host", IP: "10.0.0.1"}
+			config.FlushInterval = -1
+			exporter, err := otellog.NewExporter(config)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			appLogger, _ := logtest.NewWithConfig(t, &logger.LoggerConfig{
+				MinLevel:    logger.LevelTrace,
+				SpanContext: otellog.SpanContext,
+				Sinks:       []logger.SinkConfig{{Sink: exporter}},
+			})
+			ctx := trace.ContextWithSpanContext(context.Background(), spanContext(t))
+			appLogger.With(logger.String("order_id", "ORD-1"), logger.Int("attempt", 2), logger.Bool("retry", true)).
+				WarningCtx(ctx, "payment slow")
+			appLogger.Error("payment failed")
+			if err := appLogger.Close(); err != nil {
+				t.Fatal(err)
+			}
+
+			records := c.records()
+			if len(records) != 2 {
+				t.Fatalf("got %d records, want 2", len(records))
+			}
+			if c.headers[0] != "secret" {
+				t.Errorf("x-api-key = %q", c.headers[0])
+			}
+
+			warning := records[0]
+			if warning.GetSeverityNumber() != logspb.SeverityNumber_SEVERITY_NUMBER_WARN || warning.GetSeverityText() != "WARNING" {
+				t.Errorf("severity = %v %q", warning.GetSeverityNumber(), warning.GetSeverityText())
+			}
+			if warning.GetBody().GetStringValue() != "payment slow" {
+				t.Errorf("body = %v", warning.GetBody())
+			}
+			if hex.EncodeToString(warning.GetTraceId()) != testTraceID || hex.EncodeToString(warning.GetSpanId()) != testSpanID {
+				t.Errorf("trace = %x, span = %x", warning.GetTraceId(), warning.GetSpanId())
+			}
+			if warning.GetFlags() != 1 {
+				t.Errorf("flags = %d, want 1", warning.GetFlags())
+			}
+			attrs := attributes(warning.GetAttributes())
+			if attrs["order_id"].GetStringValue() != "ORD-1" || attrs["attempt"].GetIntValue() != 2 || !attrs["retry"].GetBoolValue() {
+				t.Errorf("attributes = %v", warning.GetAttributes())
+			}
+			if attrs["code.function.name"].GetStringValue() == "" || attrs["code.line.number"].GetIntValue() == 0 {
+				t.Errorf("missing caller attributes: %v", warning.GetAttributes())
+			}
+
+			errorRecord := records[1]
+			if errorRecord.GetSeverityNumber() != logspb.SeverityNumber_SEVERITY_NUMBER_ERROR || len(errorRecord.GetTraceId()) != 0 {
+				t.Errorf("error record = %v", errorRecord)
+			}
+
+			resourceLogs := c.requests[0].GetResourceLogs()[0]
+			resource := attributes(resourceLogs.GetResource().GetAttributes())
+			if resource["service.name"].GetStringValue() != "payment-service" || resource["host.name"].GetStringValue() != "test-host" || resource["host.ip"].GetStringValue() != "10.0.0.1" ||
+				resource["deployment.environment"].GetStringValue() != "test" {
+				t.Errorf("resource = %v", resourceLogs.GetResource().GetAttributes())
+			}
+			if scope := resourceLogs.GetScopeLogs()[0].GetScope().GetName(); scope != "github.com/funxdofficial/golang-module-syslog/logger" {
+				t.Errorf("scope = %q", scope)
+			}
+		})
+	}
+}
+
+func TestExporterResourceFromConfig(t *testing.T) {
+	tests := []struct {
+		name        string
+		serviceName string
+		want        string
+	}{
+		{"explicit service name", "payment-service", "payment-service"},
+		{"default service name", "", "unknown_service:otellog.test"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &collector{}
+			exporter, err := otellog.NewExporter(otellog.ExporterConfig{
+				Endpoint:      newHTTPCollector(t, c),
+				FlushInterval: -1,
+				ServiceName:   tt.serviceName,
+				Host:          logger.HostConfig{Hostname: "test-host", IP: "10.0.0.1"},
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer exporter.Close()
+
+			// Entry pertama tanpa ServiceName tidak menentukan resource
+			exporter.Write(&logger.LogEntry{LogLevel: "INFO", Message: "plain", ServiceName: "unknown"})
+			exporter.Write(&logger.LogEntry{LogLevel: "INFO", Message: "start", ServiceName: "other-service", TransactionID: "txn-1"})
+			if err := exporter.Flush(); err != nil {
+				t.Fatal(err)
+			}
+			exporter.Write(&logger.LogEntry{LogLevel: "INFO", Message: "next batch"})
+			if err := exporter.Flush(); err != nil {
+				t.Fatal(err)
+			}
+
+			if c.requestCount() != 2 {
+				t.Fatalf("got %d requests, want 2", c.requestCount())
+			}
+			for i, req := range c.requests {
+				resource := attributes(req.GetResourceLogs()[0].GetResource().GetAttributes())
+				if got := resource["service.name"].GetStringValue(); got != tt.want {
+					t.Errorf("request %d: service.name = %q, want %q", i, got, tt.want)
+				}
+			}
+			// ServiceName per transaksi tetap dikirim sebagai attribute record
+			if got := attributes(c.records()[1].GetAttributes())["service_name"].GetStringValue(); got != "other-service" {
+				t.Errorf("record service_name = %q", got)
+			}
+		})
+	}
+}
+
+func TestExporterSeverity(t *testing.T) {
+	c := &collector{}
+	exporter, err := otellog.NewExporter(otellog.ExporterConfig{Endpoint: newHTTPCollector(t, c), FlushInterval: -1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer exporter.Close()
+
+	want := map[string]logspb.SeverityNumber{
+		"TRACE":   logspb.SeverityNumber_SEVERITY_NUMBER_TRACE,
+		"DEBUG":   logspb.SeverityNumber_SEVERITY_NUMBER_DEBUG,
+		"INFO":    logspb.SeverityNumber_SEVERITY_NUMBER_INFO,
+		"SUCCESS": logspb.SeverityNumber_SEVERITY_NUMBER_INFO2,
+		"WARNING": logspb.SeverityNumber_SEVERITY_NUMBER_WARN,
+		"ERROR":   logspb.SeverityNumber_SEVERITY_NUMBER_ERROR,
+		"FATAL":   logspb.SeverityNumber_SEVERITY_NUMBER_FATAL,
+		"PANIC":   logspb.SeverityNumber_SEVERITY_NUMBER_FATAL2,
+		"CUSTOM":  logspb.SeverityNumber_SEVERITY_NUMBER_UNSPECIFIED,
+	}
+	for level := range want {
+		exporter.Write(&logger.LogEntry{LogLevel: level, Message: level, Time: time.Now()})
+	}
+	if err := exporter.Flush(); err != nil {
+		t.Fatal(err)
+	}
+	for _, record := range c.records() {
+		if got := record.GetSeverityNumber(); got != want[record.GetSeverityText()] {
+			t.Errorf("%s: severity = %v, want %v", record.GetSeverityText(), got, want[record.GetSeverityText()])
+		}
+	}
+}
+
+func TestExporterBatchSize(t *testing.T) {
+	c := &collector{}
+	exporter, err := otellog.NewExporter(otellog.ExporterConfig{Endpoint: newHTTPCollector(t, c), BatchSize: 2, FlushInterval: -1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer exporter.Close()
+
+	for i := 0; i < 5; i++ {
+		exporter.Write(&logger.LogEntry{LogLevel: "INFO", Message: "hello"})
+	}
+	// Dua batch penuh dikirim tanpa Flush
+	waitFor(t, func() bool { return c.requestCount() == 2 })
+
+	if err := exporter.Flush(); err != nil {
+		t.Fatal(err)
+	}
+	if c.requestCount() != 3 || len(c.records()) != 5 {
+		t.Errorf("got %d requests with %d records", c.requestCount(), len(c.records()))
+	}
+}
+
+func TestExporterDoesNotBlockOnSlowCollector(t *testing.T) {
+	c := &collector{block: make(chan struct{})}
+	exporter, err := otellog.NewExporter(otellog.ExporterConfig{
+		Endpoint:      newHTTPCollector(t, c),
+		BatchSize:     1,
+		QueueSize:     2,
+		FlushInterval: -1,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	start := time.Now()
+	for i := 0; i < 100; i++ {
+		if err := exporter.Write(&logger.LogEntry{LogLevel: "INFO", Message: "hello"}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Fatalf("Write blocked for %s", elapsed)
+	}
+	// Satu record sedang dikirim, maksimal QueueSize record menunggu di queue
+	if dropped := exporter.Dropped(); dropped < 100-1-2 {
+		t.Errorf("Dropped = %d, want at least 97", dropped)
+	}
+
+	close(c.block)
+	if err := exporter.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if got := uint64(len(c.records())) + exporter.Dropped(); got != 100 {
+		t.Errorf("exported + dropped = %d, want 100", got)
+	}
+}
+
+func TestExporterPartialSuccess(t *testing.T) {
+	c := &collector{partial: &collogspb.ExportLogsPartialSuccess{RejectedLogRecords: 1, ErrorMessage: "too large"}}
+	exporter, err := otellog.NewExporter(otellog.ExporterConfig{Protocol: otellog.ProtocolGRPC, Endpoint: newGRPCCollector(t, c), Insecure: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer exporter.Close()
+
+	exporter.Write(&logger.LogEntry{LogLevel: "INFO", Message: "hello"})
+	if err := exporter.Flush(); err == nil || !bytes.Contains([]byte(err.Error()), []byte("too large")) {
+		t.Fatalf("Flush error = %v", err)
+	}
+}
+
+func TestExporterAfterClose(t *testing.T) {
+	c := &collector{}
+	exporter, err := otellog.NewExporter(otellog.ExporterConfig{Endpoint: newHTTPCollector(t, c)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	exporter.Write(&logger.LogEntry{LogLevel: "INFO", Message: "before"})
+	if err := exporter.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if len(c.records()) != 1 {
+		t.Fatalf("Close did not export pending records")
+	}
+
+	exporter.Write(&logger.LogEntry{LogLevel: "INFO", Message: "after"})
+	if err := exporter.Flush(); err != nil || exporter.Dropped() != 1 {
+		t.Errorf("after Close: Flush = %v, Dropped = %d", err, exporter.Dropped())
+	}
+	if err := exporter.Close(); err != nil {
+		t.Errorf("second Close = %v", err)
+	}
+}
+
+func TestNewExporterConfigErrors(t *testing.T) {
+	if _, err := otellog.NewExporter(otellog.ExporterConfig{}); err == nil {
+		t.Error("expected error without Endpoint")
+	}
+	if _, err := otellog.NewExporter(otellog.ExporterConfig{Endpoint: "localhost:4318", Protocol: "thrift"}); err == nil {
+		t.Error("expected error for unsupported protocol")
+	}
+}
+
+func spanContext(t *testing.T) trace.SpanContext {
+	traceID, err := trace.TraceIDFromHex(testTraceID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	spanID, err := trace.SpanIDFromHex(testSpanID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return trace.NewSpanContext(trace.SpanContextConfig{TraceID: traceID, SpanID: spanID, TraceFlags: trace.FlagsSampled})
+}
+
+func attributes(kvs []*commonpb.KeyValue) map[string]*commonpb.AnyValue {
+	attrs := make(map[string]*commonpb.AnyValue, len(kvs))
+	for _, kv := range kvs {
+		attrs[kv.GetKey()] = kv.GetValue()
+	}
+	return attrs
+}
+
+// waitFor polls cond until it is true or the test times out after 5s
+func waitFor(t *testing.T, cond func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for !cond() {
+		if time.Now().After(deadline) {
+			t.Fatal("condition not met within 5s")
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
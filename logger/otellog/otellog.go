@@ -0,0 +1,30 @@
+// Package otellog bridges package logger with OpenTelemetry: trace context dari span aktif
+// di setiap entry, dan export entry sebagai OTLP log record ke collector.
+package otellog
+
+import (
+	"context"
+
+	"github.com/funxdofficial/golang-module-syslog/logger"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// SpanContext returns the trace ID, span ID and trace flags of the active OpenTelemetry span in ctx,
+// untuk LoggerConfig.SpanContext
+//
+//	appLogger, err := logger.StartLogger(&logger.LoggerConfig{
+//		LogFile:     "app.log",
+//		SpanContext: otellog.SpanContext,
+//	})
+func SpanContext(ctx context.Context) (logger.Correlation, bool) {
+	sc := trace.SpanContextFromContext(ctx)
+	if !sc.IsValid() {
+		return logger.Correlation{}, false
+	}
+	return logger.Correlation{
+		TraceID:    sc.TraceID().String(),
+		SpanID:     sc.SpanID().String(),
+		TraceFlags: sc.TraceFlags().String(),
+		TraceState: sc.TraceState().String(),
+	}, true
+}
@@ -0,0 +1,177 @@
+package logger
+
+import (
+	"fmt"
+	"os"
+	"time"
+)
+
+// OverflowPolicy represents what happens to a log entry when the async channel is full
+type OverflowPolicy string
+
+const (
+	OverflowBlock          OverflowPolicy = "block"              // Tunggu sampai ada ruang di channel
+	OverflowBlockTimeout   OverflowPolicy = "block-with-timeout" // Tunggu maksimal OverflowTimeout, lalu drop
+	OverflowDropNewest     OverflowPolicy = "drop-newest"        // Drop entry yang baru (default)
+	OverflowDropOldest     OverflowPolicy = "drop-oldest"        // Drop entry paling lama di channel untuk memberi ruang
+	OverflowDropBelowLevel OverflowPolicy = "drop-below-level"   // Block untuk OverflowKeepLevel ke atas, drop sisanya
+)
+
+// enqueue sends a message to the worker, applying sampling and the overflow policy when the channel is full
+func (l *Logger) enqueue(msg *logMessage) {
+	// Entry yang di-sample tidak masuk ke channel
+	if !l.sample(msg) {
+		return
+	}
+
+	// Timestamp diambil di sini (goroutine caller), bukan saat worker menulis entry
+	if msg.time.IsZero() && msg.entry == nil && msg.flushed == nil {
+		msg.time = l.now()
+	}
+	if l.sync {
+		l.writeSync(msg)
+		return
+	}
+
+	// Setelah Close worker sudah berhenti, entry tidak akan pernah ditulis
+	select {
+	case <-l.closed:
+		l.dropped.Add(1)
+		return
+	default:
+	}
+
+	// Fast path: channel belum penuh
+	select {
+	case l.logChan <- msg:
+		return
+	default:
+	}
+
+	// FATAL dan PANIC ditulis tepat sebelum exit / panic, tidak boleh di-drop oleh overflow policy
+	if levelFromString(msg.level) <= LevelPanic {
+		l.enqueueBlocking(msg)
+		return
+	}
+
+	switch l.overflowPolicy {
+	case OverflowBlock:
+		l.enqueueBlocking(msg)
+	case OverflowBlockTimeout:
+		timer := time.NewTimer(l.overflowTimeout)
+		defer timer.Stop()
+		select {
+		case l.logChan <- msg:
+		case <-l.closed:
+			l.dropped.Add(1)
+		case <-timer.C:
+			l.dropped.Add(1)
+		}
+	case OverflowDropOldest:
+		l.enqueueDropOldest(msg)
+	case OverflowDropBelowLevel:
+		if levelFromString(msg.level) <= l.overflowKeepLevel {
+			l.enqueueBlocking(msg)
+		} else {
+			l.dropped.Add(1)
+		}
+	default:
+		l.dropped.Add(1)
+	}
+}
+
+// enqueueBlocking waits until the message is accepted or the logger is closed
+func (l *Logger) enqueueBlocking(msg *logMessage) {
+	select {
+	case l.logChan <- msg:
+	case <-l.closed:
+		l.dropped.Add(1)
+	}
+}
+
+// enqueueDropOldest removes the oldest queued entry until the message fits
+func (l *Logger) enqueueDropOldest(msg *logMessage) {
+	for {
+		select {
+		case l.logChan <- msg:
+			return
+		default:
+		}
+
+		select {
+		case oldest := <-l.logChan:
+			if oldest.flushed != nil {
+				// Flush marker tidak boleh hilang: kirim ulang di belakang antrian
+				go l.enqueueBlocking(oldest)
+				continue
+			}
+			l.dropped.Add(1)
+		case <-l.closed:
+			l.dropped.Add(1)
+			return
+		default:
+			// Worker baru saja mengosongkan channel, coba kirim lagi
+		}
+	}
+}
+
+// dropQueued counts the messages left in the channel after the worker has stopped as dropped
+// (entry yang masuk bersamaan dengan Close) dan melepas barrier yang masih menunggu
+func (l *Logger) dropQueued() {
+	for {
+		select {
+		case msg, ok := <-l.logChan:
+			if !ok || msg == nil {
+				return
+			}
+			if msg.flushed != nil {
+				close(msg.flushed)
+				continue
+			}
+			l.dropped.Add(1)
+		default:
+			return
+		}
+	}
+}
+
+// Dropped returns the total number of log entries dropped because the channel was full
+// atau karena di-log setelah Close
+func (l *Logger) Dropped() uint64 {
+	return l.dropped.Load()
+}
+
+// reportDropped periodically logs a summary line when entries have been dropped
+func (l *Logger) reportDropped(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	var reported uint64
+	for {
+		select {
+		case <-ticker.C:
+			total := l.dropped.Load()
+			if total == reported {
+				continue
+			}
+			summary := fmt.Sprintf("Log channel is full: dropped %d log entries in the last %s (total: %d)",
+				total-reported, interval, total)
+			reported = total
+
+			msg := &logMessage{
+				level:    "WARNING",
+				uuid:     l.ids.NewUUID(),
+				message:  "%s",
+				args:     []interface{}{summary},
+				file:     "logger",
+				function: "reportDropped",
+			}
+			if !l.enqueueReport(msg) {
+				// Channel masih penuh, tulis langsung ke stderr
+				fmt.Fprintf(os.Stderr, "[LOGGER ERROR] %s\n", summary)
+			}
+		case <-l.closed:
+			return
+		}
+	}
+}
@@ -0,0 +1,202 @@
+package logger_test
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/funxdofficial/golang-module-syslog/logger"
+	"github.com/funxdofficial/golang-module-syslog/logger/logtest"
+)
+
+// gateSink blocks the worker on the first Write until release is called
+type gateSink struct {
+	started chan struct{}
+	gate    chan struct{}
+	once    sync.Once
+}
+
+func newGateSink() *gateSink {
+	return &gateSink{started: make(chan struct{}), gate: make(chan struct{})}
+}
+
+func (s *gateSink) Write(*logger.LogEntry) error {
+	s.once.Do(func() {
+		close(s.started)
+		<-s.gate
+	})
+	return nil
+}
+
+func (s *gateSink) Flush() error { return nil }
+func (s *gateSink) Close() error { return nil }
+
+func (s *gateSink) release() {
+	select {
+	case <-s.gate:
+	default:
+		close(s.gate)
+	}
+}
+
+// newBlockedLogger returns an async logger whose worker is blocked on "m0" and whose channel
+// (BufferSize 2) is full with "m1" and "m2"
+func newBlockedLogger(t *testing.T, config logger.LoggerConfig) (*logger.Logger, *logtest.Observer, *gateSink) {
+	t.Helper()
+	sink := newGateSink()
+	config.Mode = logger.ModeAsync
+	config.BufferSize = 2
+	config.OverflowReportInterval = -1
+	config.Sinks = []logger.SinkConfig{{Sink: sink}}
+	appLogger, logs := logtest.NewWithConfig(t, &config)
+	t.Cleanup(sink.release)
+
+	appLogger.Info("m0")
+	<-sink.started
+	appLogger.Info("m1")
+	appLogger.Info("m2")
+	return appLogger, logs, sink
+}
+
+// returnsWithin reports whether fn returns within d
+func returnsWithin(d time.Duration, fn func()) (<-chan struct{}, bool) {
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		fn()
+	}()
+	select {
+	case <-done:
+		return done, true
+	case <-time.After(d):
+		return done, false
+	}
+}
+
+func TestOverflowDropPolicies(t *testing.T) {
+	tests := []struct {
+		policy logger.OverflowPolicy
+		want   []string
+	}{
+		{logger.OverflowDropNewest, []string{"m0", "m1", "m2"}},
+		{logger.OverflowDropOldest, []string{"m0", "m3", "m4"}},
+		{logger.OverflowBlockTimeout, []string{"m0", "m1", "m2"}},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.policy), func(t *testing.T) {
+			appLogger, logs, sink := newBlockedLogger(t, logger.LoggerConfig{
+				OverflowPolicy:  tt.policy,
+				OverflowTimeout: 10 * time.Millisecond,
+			})
+
+			appLogger.Info("m3")
+			appLogger.Info("m4")
+			if appLogger.Dropped() != 2 {
+				t.Errorf("Dropped = %d, want 2", appLogger.Dropped())
+			}
+
+			sink.release()
+			if err := appLogger.Flush(context.Background()); err != nil {
+				t.Fatal(err)
+			}
+			if got := logs.All().Messages(); strings.Join(got, ",") != strings.Join(tt.want, ",") {
+				t.Errorf("written = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestOverflowBlock(t *testing.T) {
+	appLogger, logs, sink := newBlockedLogger(t, logger.LoggerConfig{OverflowPolicy: logger.OverflowBlock})
+
+	done, returned := returnsWithin(50*time.Millisecond, func() { appLogger.Info("m3") })
+	if returned {
+		t.Fatal("Info returned while the channel was full")
+	}
+	sink.release()
+	<-done
+
+	appLogger.Flush(context.Background())
+	if logs.Len() != 4 || appLogger.Dropped() != 0 {
+		t.Errorf("written %d entries, dropped %d", logs.Len(), appLogger.Dropped())
+	}
+}
+
+func TestOverflowDropBelowLevel(t *testing.T) {
+	appLogger, logs, sink := newBlockedLogger(t, logger.LoggerConfig{
+		OverflowPolicy:    logger.OverflowDropBelowLevel,
+		OverflowKeepLevel: logger.LevelWarning,
+	})
+
+	appLogger.Info("info dropped")
+	done, returned := returnsWithin(50*time.Millisecond, func() { appLogger.Warning("warning kept") })
+	if returned {
+		t.Fatal("Warning returned while the channel was full")
+	}
+	sink.release()
+	<-done
+
+	appLogger.Flush(context.Background())
+	logs.AssertLogged(t, logger.LevelWarning, "warning kept")
+	logs.AssertNotLogged(t, logger.LevelInfo, "info dropped")
+	if appLogger.Dropped() != 1 {
+		t.Errorf("Dropped = %d, want 1", appLogger.Dropped())
+	}
+}
+
+func TestOverflowNeverDropsPanic(t *testing.T) {
+	for _, policy := range []logger.OverflowPolicy{logger.OverflowDropNewest, logger.OverflowDropBelowLevel, logger.OverflowBlockTimeout} {
+		t.Run(string(policy), func(t *testing.T) {
+			appLogger, logs, sink := newBlockedLogger(t, logger.LoggerConfig{
+				OverflowPolicy:  policy,
+				OverflowTimeout: time.Millisecond,
+			})
+
+			var recovered interface{}
+			done, returned := returnsWithin(50*time.Millisecond, func() {
+				defer func() { recovered = recover() }()
+				appLogger.Panic("disk full")
+			})
+			if returned {
+				t.Fatal("Panic returned while the channel was full")
+			}
+			sink.release()
+			<-done
+
+			if recovered != "disk full" {
+				t.Errorf("recovered = %v", recovered)
+			}
+			logs.AssertLogged(t, logger.LevelPanic, "disk full")
+			if appLogger.Dropped() != 0 {
+				t.Errorf("Dropped = %d, want 0", appLogger.Dropped())
+			}
+		})
+	}
+}
+
+func TestEntriesAfterCloseAreCountedAsDropped(t *testing.T) {
+	for _, mode := range []logger.LogMode{logger.ModeAsync, logger.ModeSync} {
+		t.Run(string(mode), func(t *testing.T) {
+			appLogger, logs := logtest.NewWithConfig(t, &logger.LoggerConfig{Mode: mode})
+
+			appLogger.Info("before")
+			if err := appLogger.Close(); err != nil {
+				t.Fatal(err)
+			}
+			appLogger.Info("after")
+			appLogger.Error("after")
+
+			if got := logs.All().Messages(); len(got) != 1 || got[0] != "before" {
+				t.Errorf("written = %v", got)
+			}
+			if appLogger.Dropped() != 2 {
+				t.Errorf("Dropped = %d, want 2", appLogger.Dropped())
+			}
+			if err := appLogger.Flush(context.Background()); err != nil {
+				t.Errorf("Flush after Close = %v", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,294 @@
+package logger
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"net/http"
+	"strings"
+)
+
+// PropagationSource represents an incoming correlation header format
+type PropagationSource string
+
+const (
+	PropagationTraceparent   PropagationSource = "traceparent"      // W3C Trace Context (traceparent + tracestate)
+	PropagationB3            PropagationSource = "b3"               // Zipkin B3 (X-B3-TraceId / X-B3-SpanId atau single header b3)
+	PropagationRequestID     PropagationSource = "x-request-id"     // X-Request-ID
+	PropagationCorrelationID PropagationSource = "x-correlation-id" // X-Correlation-ID
+)
+
+// DefaultPropagation is the default priority order of correlation headers
+var DefaultPropagation = []PropagationSource{
+	PropagationTraceparent,
+	PropagationB3,
+	PropagationRequestID,
+	PropagationCorrelationID,
+}
+
+const (
+	// SpanIDKey is the key for storing the current span ID in context
+	SpanIDKey ContextKey = "logger_span_id"
+	// ParentSpanIDKey is the key for storing the parent span ID in context
+	ParentSpanIDKey ContextKey = "logger_parent_span_id"
+	// TraceFlagsKey is the key for storing W3C trace flags in context
+	TraceFlagsKey ContextKey = "logger_trace_flags"
+	// TraceStateKey is the key for storing W3C tracestate in context
+	TraceStateKey ContextKey = "logger_trace_state"
+)
+
+// Correlation holds the trace and transaction IDs of a request
+type Correlation struct {
+	TraceID       string // 32 hex, dari traceparent / B3 atau di-generate
+	SpanID        string // 16 hex, span milik service ini (selalu baru)
+	ParentSpanID  string // 16 hex, span upstream dari traceparent / B3 (kosong jika tidak ada)
+	TraceFlags    string // 2 hex, "01" = sampled
+	TraceState    string // tracestate upstream, diteruskan apa adanya
+	TransactionID string // X-Request-ID / X-Correlation-ID, atau TraceID jika tidak ada
+}
+
+// MaxRequestIDLength is the maximum length of an incoming X-Request-ID / X-Correlation-ID
+const MaxRequestIDLength = 128
+
+// requestID returns the trimmed X-Request-ID / X-Correlation-ID value, atau "" jika terlalu panjang
+// atau berisi karakter selain [A-Za-z0-9._:-]. ID dari client ditulis ke setiap baris log dan
+// response header, jadi nilai yang tidak valid diganti dengan ID baru.
+func requestID(value string) string {
+	value = strings.TrimSpace(value)
+	if len(value) > MaxRequestIDLength {
+		return ""
+	}
+	for i := 0; i < len(value); i++ {
+		c := value[i]
+		if !('a' <= c && c <= 'z' || 'A' <= c && c <= 'Z' || '0' <= c && c <= '9' || c == '.' || c == '_' || c == ':' || c == '-') {
+			return ""
+		}
+	}
+	return value
+}
+
+// Traceparent returns the W3C traceparent header value for this span
+func (c Correlation) Traceparent() string {
+	return "00-" + c.TraceID + "-" + c.SpanID + "-" + c.TraceFlags
+}
+
+// ExtractCorrelation reads correlation IDs from request headers in the given priority order
+// (nil berarti DefaultPropagation). TraceID diambil dari source trace pertama yang valid
+// (traceparent atau B3) dan TransactionID dari source ID pertama (X-Request-ID atau X-Correlation-ID).
+// ID yang tidak ada di-generate, sehingga hasilnya selalu traceparent yang valid.
+func ExtractCorrelation(header func(key string) string, order []PropagationSource) Correlation {
+	return extractCorrelation(header, order, randomIDs{})
+}
+
+// extractCorrelation is ExtractCorrelation with the IDGenerator of a logger
+func extractCorrelation(header func(key string) string, order []PropagationSource, ids IDGenerator) Correlation {
+	if order == nil {
+		order = DefaultPropagation
+	}
+
+	var c Correlation
+	for _, source := range order {
+		switch source {
+		case PropagationTraceparent:
+			if c.TraceID == "" {
+				if traceID, spanID, flags, ok := parseTraceparent(header("traceparent")); ok {
+					c.TraceID, c.ParentSpanID, c.TraceFlags = traceID, spanID, flags
+					c.TraceState = strings.TrimSpace(header("tracestate"))
+				}
+			}
+		case PropagationB3:
+			if c.TraceID == "" {
+				if traceID, spanID, flags, ok := parseB3(header); ok {
+					c.TraceID, c.ParentSpanID, c.TraceFlags = traceID, spanID, flags
+				}
+			}
+		case PropagationRequestID:
+			if c.TransactionID == "" {
+				c.TransactionID = requestID(header("X-Request-ID"))
+			}
+		case PropagationCorrelationID:
+			if c.TransactionID == "" {
+				c.TransactionID = requestID(header("X-Correlation-ID"))
+			}
+		}
+	}
+
+	if c.TraceID == "" {
+		c.TraceID = ids.NewTraceID()
+		c.TraceFlags = "01"
+	}
+	if c.TransactionID == "" {
+		c.TransactionID = c.TraceID
+	}
+	c.SpanID = ids.NewSpanID()
+	return c
+}
+
+// NewCorrelation generates a new trace with a sampled root span
+func NewCorrelation() Correlation {
+	return newCorrelation(randomIDs{})
+}
+
+// newCorrelation is NewCorrelation with the IDGenerator of a logger
+func newCorrelation(ids IDGenerator) Correlation {
+	return extractCorrelation(func(string) string { return "" }, []PropagationSource{}, ids)
+}
+
+// WithCorrelation adds trace, span and transaction IDs to context.
+// UUIDKey juga di-set ke TransactionID agar log biasa (*Ctx) memakai ID yang sama.
+func WithCorrelation(ctx context.Context, c Correlation) context.Context {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	ctx = WithUUID(ctx, c.TransactionID)
+	ctx = WithTransactionID(ctx, c.TransactionID)
+	ctx = WithTraceID(ctx, c.TraceID)
+	ctx = context.WithValue(ctx, SpanIDKey, c.SpanID)
+	ctx = context.WithValue(ctx, ParentSpanIDKey, c.ParentSpanID)
+	ctx = context.WithValue(ctx, TraceFlagsKey, c.TraceFlags)
+	ctx = context.WithValue(ctx, TraceStateKey, c.TraceState)
+	return ctx
+}
+
+// CorrelationFromContext returns the correlation IDs stored in context
+func CorrelationFromContext(ctx context.Context) Correlation {
+	return Correlation{
+		TraceID:       getValueFromContext(ctx, TraceIDKey, ""),
+		SpanID:        getValueFromContext(ctx, SpanIDKey, ""),
+		ParentSpanID:  getValueFromContext(ctx, ParentSpanIDKey, ""),
+		TraceFlags:    getValueFromContext(ctx, TraceFlagsKey, ""),
+		TraceState:    getValueFromContext(ctx, TraceStateKey, ""),
+		TransactionID: getValueFromContext(ctx, TransactionIDKey, ""),
+	}
+}
+
+// SpanContextFunc returns the trace context (TraceID, SpanID dan TraceFlags) of the active span in ctx,
+// untuk bridge tracer seperti OpenTelemetry (lihat package otellog). ok = false jika tidak ada span aktif.
+type SpanContextFunc func(ctx context.Context) (span Correlation, ok bool)
+
+// traceContext returns the trace ID, span ID and flags of ctx: dari span aktif (LoggerConfig.SpanContext)
+// jika ada, jika tidak dari WithCorrelation
+func (l *Logger) traceContext(ctx context.Context) Correlation {
+	if ctx == nil {
+		return Correlation{}
+	}
+	if l.spanCtx != nil {
+		if span, ok := l.spanCtx(ctx); ok {
+			spanID := span.SpanID
+			if child, ok := loggerSpan(ctx, span.SpanID); ok {
+				// Span dari Logger.Span di dalam span OpenTelemetry ini
+				spanID = child.id
+			}
+			return Correlation{TraceID: span.TraceID, SpanID: spanID, TraceFlags: span.TraceFlags}
+		}
+	}
+	return Correlation{
+		TraceID:    getValueFromContext(ctx, TraceIDKey, ""),
+		SpanID:     getValueFromContext(ctx, SpanIDKey, ""),
+		TraceFlags: getValueFromContext(ctx, TraceFlagsKey, ""),
+	}
+}
+
+// SetCorrelationHeaders writes traceparent, tracestate, X-Request-ID dan X-Correlation-ID.
+// Dipakai untuk response header (echo ke client) maupun request ke service lain.
+// traceparent hanya ditulis jika TraceID dan SpanID valid.
+func SetCorrelationHeaders(h http.Header, c Correlation) {
+	if isHex(c.TraceID, 32) && isHex(c.SpanID, 16) {
+		if !isHex(c.TraceFlags, 2) {
+			c.TraceFlags = "01"
+		}
+		h.Set("traceparent", c.Traceparent())
+		if c.TraceState != "" {
+			h.Set("tracestate", c.TraceState)
+		}
+	}
+	if c.TransactionID != "" {
+		h.Set("X-Request-ID", c.TransactionID)
+		h.Set("X-Correlation-ID", c.TransactionID)
+	}
+}
+
+// parseTraceparent parses a W3C traceparent header: version-traceid-parentid-flags
+func parseTraceparent(value string) (traceID, spanID, flags string, ok bool) {
+	parts := strings.Split(strings.TrimSpace(value), "-")
+	if len(parts) < 4 || !isHex(parts[0], 2) || parts[0] == "ff" {
+		return "", "", "", false
+	}
+	// Version 00 harus tepat 4 bagian, versi lebih baru boleh punya bagian tambahan
+	if parts[0] == "00" && len(parts) != 4 {
+		return "", "", "", false
+	}
+	traceID, spanID, flags = parts[1], parts[2], parts[3]
+	if !isHex(traceID, 32) || !isHex(spanID, 16) || !isHex(flags, 2) || isZero(traceID) || isZero(spanID) {
+		return "", "", "", false
+	}
+	return traceID, spanID, flags, true
+}
+
+// parseB3 parses the single b3 header or the multi X-B3-* headers
+func parseB3(header func(key string) string) (traceID, spanID, flags string, ok bool) {
+	var sampled string
+	if single := strings.TrimSpace(header("b3")); single != "" {
+		// b3: {TraceId}-{SpanId}-{SamplingState}-{ParentSpanId}
+		parts := strings.Split(single, "-")
+		if len(parts) < 2 {
+			return "", "", "", false
+		}
+		traceID, spanID = parts[0], parts[1]
+		if len(parts) > 2 {
+			sampled = parts[2]
+		}
+	} else {
+		traceID = strings.TrimSpace(header("X-B3-TraceId"))
+		spanID = strings.TrimSpace(header("X-B3-SpanId"))
+		sampled = strings.TrimSpace(header("X-B3-Sampled"))
+		if header("X-B3-Flags") == "1" {
+			sampled = "d"
+		}
+	}
+
+	traceID = strings.ToLower(traceID)
+	spanID = strings.ToLower(spanID)
+	// Trace ID 64-bit di-pad menjadi 128-bit
+	if isHex(traceID, 16) {
+		traceID = strings.Repeat("0", 16) + traceID
+	}
+	if !isHex(traceID, 32) || !isHex(spanID, 16) || isZero(traceID) || isZero(spanID) {
+		return "", "", "", false
+	}
+
+	flags = "01"
+	if sampled == "0" || sampled == "false" {
+		flags = "00"
+	}
+	return traceID, spanID, flags, true
+}
+
+// isHex reports whether s is exactly n lowercase hex characters
+func isHex(s string, n int) bool {
+	if len(s) != n {
+		return false
+	}
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if (c < '0' || c > '9') && (c < 'a' || c > 'f') {
+			return false
+		}
+	}
+	return true
+}
+
+// isZero reports whether a hex ID is all zeros (invalid per W3C Trace Context)
+func isZero(s string) bool {
+	return strings.Trim(s, "0") == ""
+}
+
+// randomHex returns n random bytes encoded as lowercase hex
+func randomHex(n int) string {
+	b := make([]byte, n)
+	if _, err := rand.Read(b); err != nil {
+		// crypto/rand tidak pernah gagal di platform yang didukung, fallback ke UUID
+		return strings.ReplaceAll(generateUUID(), "-", "")[:n*2]
+	}
+	return hex.EncodeToString(b)
+}
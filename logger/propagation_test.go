@@ -0,0 +1,195 @@
+package logger_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/funxdofficial/golang-module-syslog/logger"
+	"github.com/funxdofficial/golang-module-syslog/logger/logtest"
+)
+
+const (
+	testTraceID = "4bf92f3577b34da6a3ce929d0e0e4736"
+	testSpanID  = "00f067aa0ba902b7"
+)
+
+func TestExtractCorrelation(t *testing.T) {
+	tests := []struct {
+		name    string
+		headers map[string]string
+		order   []logger.PropagationSource
+
+		traceID      string // "" = di-generate
+		parentSpanID string
+		flags        string
+		txn          string // "" = sama dengan trace ID
+	}{
+		{
+			name:         "traceparent",
+			headers:      map[string]string{"traceparent": "00-" + testTraceID + "-" + testSpanID + "-01", "tracestate": "vendor=1"},
+			traceID:      testTraceID,
+			parentSpanID: testSpanID,
+			flags:        "01",
+		},
+		{
+			name:         "traceparent not sampled",
+			headers:      map[string]string{"traceparent": "00-" + testTraceID + "-" + testSpanID + "-00"},
+			traceID:      testTraceID,
+			parentSpanID: testSpanID,
+			flags:        "00",
+		},
+		{
+			name:         "traceparent future version with extra part",
+			headers:      map[string]string{"traceparent": "01-" + testTraceID + "-" + testSpanID + "-01-extra"},
+			traceID:      testTraceID,
+			parentSpanID: testSpanID,
+			flags:        "01",
+		},
+		{name: "traceparent version 00 with extra part", headers: map[string]string{"traceparent": "00-" + testTraceID + "-" + testSpanID + "-01-extra"}},
+		{name: "traceparent version ff", headers: map[string]string{"traceparent": "ff-" + testTraceID + "-" + testSpanID + "-01"}},
+		{name: "traceparent zero trace ID", headers: map[string]string{"traceparent": "00-" + strings.Repeat("0", 32) + "-" + testSpanID + "-01"}},
+		{name: "traceparent zero span ID", headers: map[string]string{"traceparent": "00-" + testTraceID + "-" + strings.Repeat("0", 16) + "-01"}},
+		{name: "traceparent uppercase", headers: map[string]string{"traceparent": "00-" + strings.ToUpper(testTraceID) + "-" + testSpanID + "-01"}},
+		{name: "traceparent short trace ID", headers: map[string]string{"traceparent": "00-4bf92f35-" + testSpanID + "-01"}},
+		{
+			name:         "b3 single header",
+			headers:      map[string]string{"b3": testTraceID + "-" + testSpanID + "-1"},
+			traceID:      testTraceID,
+			parentSpanID: testSpanID,
+			flags:        "01",
+		},
+		{
+			name:         "b3 single header not sampled",
+			headers:      map[string]string{"b3": testTraceID + "-" + testSpanID + "-0"},
+			traceID:      testTraceID,
+			parentSpanID: testSpanID,
+			flags:        "00",
+		},
+		{
+			name:         "b3 multi header with 64-bit trace ID",
+			headers:      map[string]string{"X-B3-TraceId": "A3CE929D0E0E4736", "X-B3-SpanId": testSpanID, "X-B3-Sampled": "1"},
+			traceID:      "0000000000000000a3ce929d0e0e4736",
+			parentSpanID: testSpanID,
+			flags:        "01",
+		},
+		{
+			name:         "b3 debug flag",
+			headers:      map[string]string{"X-B3-TraceId": testTraceID, "X-B3-SpanId": testSpanID, "X-B3-Sampled": "0", "X-B3-Flags": "1"},
+			traceID:      testTraceID,
+			parentSpanID: testSpanID,
+			flags:        "01",
+		},
+		{name: "b3 missing span ID", headers: map[string]string{"b3": testTraceID}},
+		{
+			name: "traceparent wins over b3 by default",
+			headers: map[string]string{
+				"traceparent": "00-" + testTraceID + "-" + testSpanID + "-01",
+				"b3":          "0000000000000000a3ce929d0e0e4736-a3ce929d0e0e4736-1",
+			},
+			traceID:      testTraceID,
+			parentSpanID: testSpanID,
+			flags:        "01",
+		},
+		{
+			name: "custom order puts b3 first",
+			headers: map[string]string{
+				"traceparent": "00-" + testTraceID + "-" + testSpanID + "-01",
+				"b3":          "0000000000000000a3ce929d0e0e4736-a3ce929d0e0e4736-1",
+			},
+			order:        []logger.PropagationSource{logger.PropagationB3, logger.PropagationTraceparent},
+			traceID:      "0000000000000000a3ce929d0e0e4736",
+			parentSpanID: "a3ce929d0e0e4736",
+			flags:        "01",
+		},
+		{
+			name:    "source not in order is ignored",
+			headers: map[string]string{"traceparent": "00-" + testTraceID + "-" + testSpanID + "-01"},
+			order:   []logger.PropagationSource{logger.PropagationB3},
+		},
+		{name: "request ID", headers: map[string]string{"X-Request-ID": " req-123 "}, txn: "req-123"},
+		{name: "correlation ID", headers: map[string]string{"X-Correlation-ID": "corr:1.a_b"}, txn: "corr:1.a_b"},
+		{name: "request ID wins over correlation ID", headers: map[string]string{"X-Request-ID": "req", "X-Correlation-ID": "corr"}, txn: "req"},
+		{name: "request ID too long", headers: map[string]string{"X-Request-ID": strings.Repeat("a", logger.MaxRequestIDLength+1)}},
+		{name: "request ID max length", headers: map[string]string{"X-Request-ID": strings.Repeat("a", logger.MaxRequestIDLength)}, txn: strings.Repeat("a", logger.MaxRequestIDLength)},
+		{name: "request ID with invalid characters", headers: map[string]string{"X-Request-ID": "id\x1b[31m<script>"}},
+		{name: "invalid request ID falls back to correlation ID", headers: map[string]string{"X-Request-ID": "a b", "X-Correlation-ID": "corr"}, txn: "corr"},
+		{name: "no headers"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := logger.ExtractCorrelation(func(key string) string { return tt.headers[key] }, tt.order)
+
+			if tt.traceID != "" && c.TraceID != tt.traceID {
+				t.Errorf("TraceID = %q, want %q", c.TraceID, tt.traceID)
+			}
+			if tt.traceID == "" && (c.TraceID == testTraceID || len(c.TraceID) != 32 || c.TraceFlags != "01") {
+				t.Errorf("expected a new sampled trace, got %q flags %q", c.TraceID, c.TraceFlags)
+			}
+			if c.ParentSpanID != tt.parentSpanID {
+				t.Errorf("ParentSpanID = %q, want %q", c.ParentSpanID, tt.parentSpanID)
+			}
+			if tt.flags != "" && c.TraceFlags != tt.flags {
+				t.Errorf("TraceFlags = %q, want %q", c.TraceFlags, tt.flags)
+			}
+			wantTxn := tt.txn
+			if wantTxn == "" {
+				wantTxn = c.TraceID
+			}
+			if c.TransactionID != wantTxn {
+				t.Errorf("TransactionID = %q, want %q", c.TransactionID, wantTxn)
+			}
+			if len(c.SpanID) != 16 || c.SpanID == tt.parentSpanID {
+				t.Errorf("SpanID = %q, want a new span", c.SpanID)
+			}
+		})
+	}
+}
+
+func TestMiddlewarePropagatesCorrelation(t *testing.T) {
+	appLogger, logs := logtest.New(t)
+
+	handler := appLogger.StandardHTTPMiddleware(logger.MiddlewareConfig{})(
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			appLogger.InfoCtx(r.Context(), "handled")
+		}))
+	req := httptest.NewRequest("GET", "/orders", nil)
+	req.Header.Set("traceparent", "00-"+testTraceID+"-"+testSpanID+"-01")
+	req.Header.Set("tracestate", "vendor=1")
+	req.Header.Set("X-Request-ID", "req-42")
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+
+	entry := logs.AssertLogged(t, logger.LevelInfo, "handled")
+	if entry.UUID != "req-42" || entry.TraceID != testTraceID {
+		t.Errorf("entry UUID = %q, TraceID = %q", entry.UUID, entry.TraceID)
+	}
+	logs.AssertTransaction(t, "req-42")
+
+	traceparent := rec.Header().Get("traceparent")
+	if !strings.HasPrefix(traceparent, "00-"+testTraceID+"-") || strings.Contains(traceparent, testSpanID) {
+		t.Errorf("response traceparent = %q, want same trace with a new span", traceparent)
+	}
+	if rec.Header().Get("tracestate") != "vendor=1" || rec.Header().Get("X-Request-ID") != "req-42" {
+		t.Errorf("response headers = %v", rec.Header())
+	}
+}
+
+func TestMiddlewareReplacesInvalidRequestID(t *testing.T) {
+	appLogger, logs := logtest.New(t)
+
+	handler := appLogger.StandardHTTPMiddleware(logger.MiddlewareConfig{})(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {}))
+	req := httptest.NewRequest("GET", "/", nil)
+	req.Header.Set("X-Request-ID", strings.Repeat("x", 10000))
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+
+	start := logs.All().Flag(logger.FlagStart)
+	if len(start) != 1 || len(start[0].TransactionID) > logger.MaxRequestIDLength {
+		t.Fatalf("START transaction ID not replaced: %d bytes", len(start[0].TransactionID))
+	}
+	if got := rec.Header().Get("X-Request-ID"); got != start[0].TransactionID {
+		t.Errorf("response X-Request-ID = %q, want %q", got, start[0].TransactionID)
+	}
+}
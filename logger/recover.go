@@ -0,0 +1,49 @@
+package logger
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// defaultPanicContentType is the Content-Type of the default panic response
+const defaultPanicContentType = "text/plain; charset=utf-8"
+
+// WritePanicResponse writes the 500 response configured by PanicBody and PanicContentType
+func (c MiddlewareConfig) WritePanicResponse(w http.ResponseWriter) {
+	body := c.PanicBody
+	if body == "" {
+		body = http.StatusText(http.StatusInternalServerError)
+	}
+	contentType := c.PanicContentType
+	if contentType == "" {
+		contentType = defaultPanicContentType
+	}
+
+	w.Header().Set("Content-Type", contentType)
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.WriteHeader(http.StatusInternalServerError)
+	io.WriteString(w, body)
+}
+
+// StopPanic writes the STOP entry of a recovered panic: ERROR dengan field "panic" (nilai panic)
+// dan "stack" (stack trace goroutine), serta correlation fields dari ctx.
+// http.ErrAbortHandler (request sengaja dibatalkan) ditulis sebagai WARNING tanpa stack.
+func (l *Logger) StopPanic(ctx context.Context, value interface{}, stack []byte, body string) {
+	if value == http.ErrAbortHandler {
+		l.Stop(ctx, "WARNING", "Request aborted", body)
+		return
+	}
+	fields := []Field{
+		String("panic", fmt.Sprint(value)),
+		String("stack", string(stack)),
+	}
+	l.Stop(WithFields(ctx, fields...), "ERROR", "Request panicked", body)
+}
+
+// ShouldRepanic reports whether a recovered panic value must be re-raised:
+// selalu untuk http.ErrAbortHandler, dan untuk panic lain jika Repanic aktif
+func (c MiddlewareConfig) ShouldRepanic(value interface{}) bool {
+	return c.Repanic || value == http.ErrAbortHandler
+}
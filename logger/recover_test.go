@@ -0,0 +1,133 @@
+package logger_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/funxdofficial/golang-module-syslog/logger"
+	"github.com/funxdofficial/golang-module-syslog/logger/logtest"
+)
+
+// serveRecovered serves one request through the middleware and returns the recorder and
+// the value re-panicked by the middleware (nil jika tidak ada)
+func serveRecovered(t *testing.T, appLogger *logger.Logger, config logger.MiddlewareConfig, handler http.HandlerFunc) (rec *httptest.ResponseRecorder, repanicked interface{}) {
+	t.Helper()
+	config.RecoverPanic = true
+	h := appLogger.StandardHTTPMiddleware(config)(handler)
+
+	req := httptest.NewRequest(http.MethodGet, "/orders", nil)
+	req.Header.Set("X-Request-ID", "req-panic")
+	rec = httptest.NewRecorder()
+	defer func() {
+		repanicked = recover()
+	}()
+	h.ServeHTTP(rec, req)
+	return rec, nil
+}
+
+func panickingHandler(w http.ResponseWriter, r *http.Request) {
+	panic("boom")
+}
+
+func TestRecoverPanicLogsStackAndWrites500(t *testing.T) {
+	appLogger, logs := logtest.New(t)
+	rec, repanicked := serveRecovered(t, appLogger, logger.MiddlewareConfig{}, panickingHandler)
+
+	if repanicked != nil {
+		t.Fatalf("middleware re-panicked with %v, want recovered", repanicked)
+	}
+	if rec.Code != http.StatusInternalServerError {
+		t.Errorf("status = %d, want 500", rec.Code)
+	}
+	if got := rec.Body.String(); got != "Internal Server Error" {
+		t.Errorf("body = %q, want Internal Server Error", got)
+	}
+	if got := rec.Header().Get("Content-Type"); got != "text/plain; charset=utf-8" {
+		t.Errorf("Content-Type = %q", got)
+	}
+
+	stop := logs.AssertTransaction(t, "req-panic").Flag(logger.FlagStop)[0]
+	if stop.Level() != logger.LevelError || stop.Message != "Request panicked" {
+		t.Errorf("STOP = %s %q, want ERROR Request panicked", stop.Level(), stop.Message)
+	}
+	if value, _ := stop.Field("panic"); value != "boom" {
+		t.Errorf("field panic = %v, want boom", value)
+	}
+	stack, _ := stop.Field("stack")
+	if s, _ := stack.(string); !strings.Contains(s, "panickingHandler") {
+		t.Errorf("field stack does not contain the panicking function:\n%s", s)
+	}
+	if status, _ := stop.Field("status"); status != int64(http.StatusInternalServerError) {
+		t.Errorf("field status = %v, want 500", status)
+	}
+}
+
+func TestRecoverPanicCustomResponse(t *testing.T) {
+	appLogger, _ := logtest.New(t)
+	rec, _ := serveRecovered(t, appLogger, logger.MiddlewareConfig{
+		PanicBody:        `{"error":"internal"}`,
+		PanicContentType: "application/json",
+	}, panickingHandler)
+
+	if rec.Code != http.StatusInternalServerError || rec.Body.String() != `{"error":"internal"}` {
+		t.Errorf("response = %d %q", rec.Code, rec.Body.String())
+	}
+	if got := rec.Header().Get("Content-Type"); got != "application/json" {
+		t.Errorf("Content-Type = %q, want application/json", got)
+	}
+}
+
+func TestRecoverPanicAfterWrite(t *testing.T) {
+	appLogger, logs := logtest.New(t)
+	rec, _ := serveRecovered(t, appLogger, logger.MiddlewareConfig{}, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusAccepted)
+		w.Write([]byte("partial"))
+		panic("late")
+	})
+
+	// Response yang sudah ditulis tidak boleh ditimpa
+	if rec.Code != http.StatusAccepted || rec.Body.String() != "partial" {
+		t.Errorf("response = %d %q, want 202 partial", rec.Code, rec.Body.String())
+	}
+	stop := logs.AssertTransaction(t, "req-panic").Flag(logger.FlagStop)[0]
+	if status, _ := stop.Field("status"); status != int64(http.StatusAccepted) {
+		t.Errorf("field status = %v, want 202", status)
+	}
+}
+
+func TestRecoverRepanicsOnErrAbortHandler(t *testing.T) {
+	appLogger, logs := logtest.New(t)
+	rec, repanicked := serveRecovered(t, appLogger, logger.MiddlewareConfig{}, func(w http.ResponseWriter, r *http.Request) {
+		panic(http.ErrAbortHandler)
+	})
+
+	if repanicked != http.ErrAbortHandler {
+		t.Fatalf("re-panicked with %v, want http.ErrAbortHandler", repanicked)
+	}
+	if rec.Code == http.StatusInternalServerError || rec.Body.Len() > 0 {
+		t.Errorf("aborted request must not get a 500 response, got %d %q", rec.Code, rec.Body.String())
+	}
+
+	stop := logs.AssertTransaction(t, "req-panic").Flag(logger.FlagStop)[0]
+	if stop.Level() != logger.LevelWarning || stop.Message != "Request aborted" {
+		t.Errorf("STOP = %s %q, want WARNING Request aborted", stop.Level(), stop.Message)
+	}
+	if _, ok := stop.Field("stack"); ok {
+		t.Error("aborted request must not log a stack trace")
+	}
+}
+
+func TestRecoverRepanic(t *testing.T) {
+	appLogger, logs := logtest.New(t)
+	rec, repanicked := serveRecovered(t, appLogger, logger.MiddlewareConfig{Repanic: true}, panickingHandler)
+
+	if repanicked != "boom" {
+		t.Fatalf("re-panicked with %v, want boom", repanicked)
+	}
+	if rec.Code != http.StatusInternalServerError {
+		t.Errorf("status = %d, want 500", rec.Code)
+	}
+	logs.AssertCount(t, logger.LevelError, 1)
+}
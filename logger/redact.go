@@ -0,0 +1,475 @@
+package logger
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+)
+
+// RedactStrategy menentukan bagaimana nilai sensitif diganti
+type RedactStrategy string
+
+const (
+	RedactFull  RedactStrategy = "full"  // Ganti seluruh nilai dengan "[REDACTED]" (default)
+	RedactLast4 RedactStrategy = "last4" // Simpan 4 karakter terakhir: "************1111"
+	RedactHash  RedactStrategy = "hash"  // SHA-256 (16 hex pertama): "sha256:9f86d081884c7d65", bisa dipakai untuk korelasi
+)
+
+// redactedValue is the replacement written by RedactFull
+const redactedValue = "[REDACTED]"
+
+// RedactRule is a regex rule applied to every message, body and string field
+type RedactRule struct {
+	Name     string         // Nama rule (dokumentasi saja)
+	Pattern  *regexp.Regexp // Nilai yang cocok akan di-mask
+	Strategy RedactStrategy // Default: RedactFull
+	// Validate menyaring match yang bukan data sensitif (misalnya Luhn check untuk PAN), nil = semua match
+	Validate func(match string) bool
+}
+
+// Built-in rules
+var (
+	// RulePAN masks card numbers (13-19 digit, boleh dipisah spasi atau "-") yang lolos Luhn check
+	RulePAN = RedactRule{
+		Name:     "pan",
+		Pattern:  regexp.MustCompile(`\b\d(?:[ -]?\d){12,18}\b`),
+		Strategy: RedactLast4,
+		Validate: luhnValid,
+	}
+	// RuleEmail masks email addresses
+	RuleEmail = RedactRule{
+		Name:     "email",
+		Pattern:  regexp.MustCompile(`[A-Za-z0-9._%+-]+@[A-Za-z0-9.-]+\.[A-Za-z]{2,}`),
+		Strategy: RedactFull,
+	}
+	// RulePhone masks phone numbers dengan kode negara "+" (misalnya +62 812-3456-7890).
+	// Nomor lokal berawalan 0 tidak di-mask karena tidak bisa dibedakan dari order ID atau nomor lain.
+	RulePhone = RedactRule{
+		Name:     "phone",
+		Pattern:  regexp.MustCompile(`\+\d{1,3}[ -]?\d{2,4}[ -]?\d{3,4}[ -]?\d{3,5}\b`),
+		Strategy: RedactLast4,
+	}
+	// RuleNationalID masks 16 digit national ID numbers (NIK)
+	RuleNationalID = RedactRule{
+		Name:     "national_id",
+		Pattern:  regexp.MustCompile(`\b\d{16}\b`),
+		Strategy: RedactFull,
+	}
+)
+
+// DefaultRedactKeys are the key names masked when RedactConfig.Keys is nil
+var DefaultRedactKeys = []string{
+	"password", "passwd", "pwd", "secret", "token", "access_token", "refresh_token", "id_token",
+	"authorization", "api_key", "apikey", "client_secret", "card_number", "cvv", "cvc", "pin",
+}
+
+// DefaultRedactHeaders are the headers masked when RedactConfig.Headers is nil
+var DefaultRedactHeaders = []string{
+	"Authorization", "Proxy-Authorization", "Cookie", "Set-Cookie", "X-Api-Key", "X-Auth-Token",
+}
+
+// DefaultRedactRules are the regex rules used when RedactConfig.Rules is nil.
+// RulePAN dijalankan sebelum RuleNationalID sehingga nomor kartu 16 digit tetap menyimpan 4 digit terakhir.
+var DefaultRedactRules = []RedactRule{RulePAN, RuleEmail, RulePhone, RuleNationalID}
+
+// RedactConfig untuk konfigurasi redaction
+type RedactConfig struct {
+	// Keys adalah nama key (case-insensitive) pada JSON body, form/query body, "key=value" di message
+	// dan structured fields (default: DefaultRedactKeys, []string{} untuk nonaktif)
+	Keys []string
+	// Paths adalah JSON path pada body, misalnya "$.user.password" atau "items[*].card.number".
+	// Index array diabaikan dan segment "*" cocok dengan key apa pun.
+	Paths []string
+	// Rules adalah regex rules untuk semua teks (default: DefaultRedactRules, []RedactRule{} untuk nonaktif)
+	Rules []RedactRule
+	// Headers adalah header deny-list (default: DefaultRedactHeaders): dipakai oleh RedactHeaders,
+	// untuk teks "Header: value" di message dan body, serta key JSON dan structured fields dengan nama yang sama
+	Headers []string
+	// Strategy untuk nilai dari Keys, Paths dan Headers (default: RedactFull)
+	Strategy RedactStrategy
+}
+
+// Redactor masks sensitive data in log entries. Redactor immutable dan aman dipakai dari banyak goroutine;
+// method-nya aman dipanggil pada nil (tanpa redaction).
+type Redactor struct {
+	keys     map[string]bool
+	paths    [][]string
+	rules    []RedactRule
+	headers  map[string]bool
+	strategy RedactStrategy
+
+	jsonKeyPattern   *regexp.Regexp // "key": value, juga untuk JSON yang terpotong
+	kvKeyPattern     *regexp.Regexp // key=value (form, query string, logfmt)
+	headerKeyPattern *regexp.Regexp // Key: value sampai akhir baris (header HTTP, teks bebas)
+}
+
+// NewRedactor compiles config into a Redactor
+//
+//	redactor := logger.NewRedactor(logger.RedactConfig{
+//		Paths: []string{"$.customer.address"},
+//	})
+func NewRedactor(config RedactConfig) *Redactor {
+	keys := config.Keys
+	if keys == nil {
+		keys = DefaultRedactKeys
+	}
+	rules := config.Rules
+	if rules == nil {
+		rules = DefaultRedactRules
+	}
+	headers := config.Headers
+	if headers == nil {
+		headers = DefaultRedactHeaders
+	}
+
+	r := &Redactor{
+		keys:     make(map[string]bool, len(keys)),
+		headers:  make(map[string]bool, len(headers)),
+		rules:    rules,
+		strategy: config.Strategy,
+	}
+	for _, key := range keys {
+		r.keys[strings.ToLower(key)] = true
+	}
+	for _, header := range headers {
+		r.headers[http.CanonicalHeaderKey(header)] = true
+	}
+	for _, path := range config.Paths {
+		r.paths = append(r.paths, parseRedactPath(path))
+	}
+
+	// Pattern teks memakai key dan header deny-list sekaligus
+	var quoted []string
+	for _, name := range append(keys[:len(keys):len(keys)], headers...) {
+		quoted = append(quoted, regexp.QuoteMeta(name))
+	}
+	if len(quoted) > 0 {
+		names := strings.Join(quoted, "|")
+		r.jsonKeyPattern = regexp.MustCompile(`(?i)("(?:` + names + `)"\s*:\s*)("(?:[^"\\]|\\.)*"?|[^,}\]\s]+)`)
+		r.kvKeyPattern = regexp.MustCompile(`(?i)(\b(?:` + names + `)=)([^&\s]*)`)
+		r.headerKeyPattern = regexp.MustCompile(`(?im)(\b(?:` + names + `)[ \t]*:[ \t]*)([^\r\n"]+)`)
+	}
+	return r
+}
+
+// redactPathIndex matches array indexes in a JSON path ("[0]", "[*]")
+var redactPathIndex = regexp.MustCompile(`\[[^\]]*\]`)
+
+// parseRedactPath splits a JSON path into key segments, dropping "$" and array indexes
+func parseRedactPath(path string) []string {
+	path = redactPathIndex.ReplaceAllString(path, "")
+	path = strings.TrimPrefix(path, "$")
+	path = strings.Trim(path, ".")
+	if path == "" {
+		return nil
+	}
+	return strings.Split(path, ".")
+}
+
+// RedactString applies the key=value masking and regex rules to s
+func (r *Redactor) RedactString(s string) string {
+	if r == nil || s == "" {
+		return s
+	}
+	s = r.redactKeyValues(s)
+	return r.applyRules(s)
+}
+
+// RedactBody masks a request or response body. Body JSON yang valid di-walk sehingga Keys dan Paths
+// berlaku di semua level (urutan key tetap); body lain (form, JSON terpotong, teks) memakai RedactString.
+func (r *Redactor) RedactBody(body string) string {
+	if r == nil || body == "" {
+		return body
+	}
+	if trimmed := strings.TrimSpace(body); strings.HasPrefix(trimmed, "{") || strings.HasPrefix(trimmed, "[") {
+		if redacted, ok := r.redactJSON(trimmed); ok {
+			return redacted
+		}
+	}
+	return r.RedactString(body)
+}
+
+// RedactFields returns fields with sensitive values masked. Slice asli tidak diubah.
+func (r *Redactor) RedactFields(fields []Field) []Field {
+	if r == nil || len(fields) == 0 {
+		return fields
+	}
+	var out []Field
+	for i, f := range fields {
+		value := f.ValueString()
+		var redacted string
+		if r.sensitiveKey(f.Key) {
+			redacted = maskValue(value, r.strategy)
+		} else {
+			redacted = r.RedactString(value)
+		}
+		if redacted == value {
+			continue
+		}
+		if out == nil {
+			out = append([]Field(nil), fields...)
+		}
+		out[i] = String(f.Key, redacted)
+	}
+	if out == nil {
+		return fields
+	}
+	return out
+}
+
+// RedactHeaders returns a copy of h with the deny-listed headers masked
+func (r *Redactor) RedactHeaders(h http.Header) http.Header {
+	if r == nil || h == nil {
+		return h
+	}
+	out := h.Clone()
+	for key, values := range out {
+		if !r.headers[http.CanonicalHeaderKey(key)] {
+			continue
+		}
+		for i, v := range values {
+			values[i] = maskValue(v, r.strategy)
+		}
+	}
+	return out
+}
+
+// RedactEntry masks the message, body and fields of entry in place
+func (r *Redactor) RedactEntry(entry *LogEntry) {
+	if r == nil || entry == nil {
+		return
+	}
+	entry.Message = r.RedactString(entry.Message)
+	entry.Body = r.RedactBody(entry.Body)
+	entry.Fields = r.RedactFields(entry.Fields)
+}
+
+// redactKeyValues masks the values of sensitive keys in "key": value, key=value and Key: value form
+func (r *Redactor) redactKeyValues(s string) string {
+	if r.jsonKeyPattern == nil {
+		return s
+	}
+	s = r.jsonKeyPattern.ReplaceAllStringFunc(s, func(match string) string {
+		m := r.jsonKeyPattern.FindStringSubmatch(match)
+		value := m[2]
+		if strings.HasPrefix(value, `"`) {
+			var unquoted string
+			if err := json.Unmarshal([]byte(value), &unquoted); err != nil {
+				// String terpotong tanpa tanda kutip penutup
+				unquoted = strings.TrimPrefix(value, `"`)
+			}
+			return m[1] + quoteJSON(maskValue(unquoted, r.strategy))
+		}
+		return m[1] + quoteJSON(maskValue(value, r.strategy))
+	})
+	s = r.kvKeyPattern.ReplaceAllStringFunc(s, func(match string) string {
+		m := r.kvKeyPattern.FindStringSubmatch(match)
+		if m[2] == "" {
+			return match
+		}
+		return m[1] + maskValue(m[2], r.strategy)
+	})
+	return r.headerKeyPattern.ReplaceAllStringFunc(s, func(match string) string {
+		m := r.headerKeyPattern.FindStringSubmatch(match)
+		value := strings.TrimRight(m[2], " \t")
+		if value == "" || value == redactedValue {
+			return match
+		}
+		return m[1] + maskValue(value, r.strategy) + m[2][len(value):]
+	})
+}
+
+// applyRules runs every regex rule over s
+func (r *Redactor) applyRules(s string) string {
+	for _, rule := range r.rules {
+		if rule.Pattern == nil {
+			continue
+		}
+		s = rule.Pattern.ReplaceAllStringFunc(s, func(match string) string {
+			if rule.Validate != nil && !rule.Validate(match) {
+				return match
+			}
+			return maskValue(match, rule.Strategy)
+		})
+	}
+	return s
+}
+
+// sensitiveKey reports whether key is in the key or header deny-list
+func (r *Redactor) sensitiveKey(key string) bool {
+	return r.keys[strings.ToLower(key)] || r.headers[http.CanonicalHeaderKey(key)]
+}
+
+// sensitive reports whether the value at path (ending with key) must be masked
+func (r *Redactor) sensitive(key string, path []string) bool {
+	if r.sensitiveKey(key) {
+		return true
+	}
+	for _, p := range r.paths {
+		if matchRedactPath(p, path) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchRedactPath reports whether path matches pattern; segment "*" cocok dengan key apa pun
+func matchRedactPath(pattern []string, path []string) bool {
+	if len(pattern) != len(path) {
+		return false
+	}
+	for i := range pattern {
+		if pattern[i] != "*" && !strings.EqualFold(pattern[i], path[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// redactJSON re-encodes a JSON document token by token, masking sensitive values
+func (r *Redactor) redactJSON(body string) (string, bool) {
+	dec := json.NewDecoder(strings.NewReader(body))
+	dec.UseNumber()
+
+	var out strings.Builder
+	if err := r.redactJSONValue(dec, &out, nil); err != nil {
+		return "", false
+	}
+	// Harus tepat satu JSON value
+	if _, err := dec.Token(); !errors.Is(err, io.EOF) {
+		return "", false
+	}
+	return out.String(), true
+}
+
+func (r *Redactor) redactJSONValue(dec *json.Decoder, out *strings.Builder, path []string) error {
+	tok, err := dec.Token()
+	if err != nil {
+		return err
+	}
+
+	switch t := tok.(type) {
+	case json.Delim:
+		if t == '[' {
+			// Index array tidak menambah segment path
+			out.WriteByte('[')
+			for i := 0; dec.More(); i++ {
+				if i > 0 {
+					out.WriteByte(',')
+				}
+				if err := r.redactJSONValue(dec, out, path); err != nil {
+					return err
+				}
+			}
+			out.WriteByte(']')
+		} else {
+			out.WriteByte('{')
+			for i := 0; dec.More(); i++ {
+				keyTok, err := dec.Token()
+				if err != nil {
+					return err
+				}
+				key, _ := keyTok.(string)
+				if i > 0 {
+					out.WriteByte(',')
+				}
+				out.WriteString(quoteJSON(key))
+				out.WriteByte(':')
+
+				keyPath := append(path[:len(path):len(path)], key)
+				if r.sensitive(key, keyPath) {
+					var raw json.RawMessage
+					if err := dec.Decode(&raw); err != nil {
+						return err
+					}
+					out.WriteString(quoteJSON(maskValue(rawJSONText(raw), r.strategy)))
+					continue
+				}
+				if err := r.redactJSONValue(dec, out, keyPath); err != nil {
+					return err
+				}
+			}
+			out.WriteByte('}')
+		}
+		// Delimiter penutup
+		_, err = dec.Token()
+		return err
+	case string:
+		out.WriteString(quoteJSON(r.RedactString(t)))
+	case json.Number:
+		// Nomor kartu bisa dikirim sebagai angka
+		if s := r.applyRules(t.String()); s != t.String() {
+			out.WriteString(quoteJSON(s))
+		} else {
+			out.WriteString(t.String())
+		}
+	case bool:
+		if t {
+			out.WriteString("true")
+		} else {
+			out.WriteString("false")
+		}
+	case nil:
+		out.WriteString("null")
+	}
+	return nil
+}
+
+// rawJSONText returns the text of a JSON string, atau JSON apa adanya untuk value lain
+func rawJSONText(raw json.RawMessage) string {
+	var s string
+	if err := json.Unmarshal(raw, &s); err == nil {
+		return s
+	}
+	return string(raw)
+}
+
+// quoteJSON encodes s as a JSON string
+func quoteJSON(s string) string {
+	b, _ := json.Marshal(s)
+	return string(b)
+}
+
+// maskValue replaces value according to strategy
+func maskValue(value string, strategy RedactStrategy) string {
+	switch strategy {
+	case RedactLast4:
+		n := utf8.RuneCountInString(value)
+		if n <= 4 {
+			return strings.Repeat("*", n)
+		}
+		runes := []rune(value)
+		return strings.Repeat("*", n-4) + string(runes[n-4:])
+	case RedactHash:
+		sum := sha256.Sum256([]byte(value))
+		return "sha256:" + hex.EncodeToString(sum[:8])
+	}
+	return redactedValue
+}
+
+// luhnValid reports whether the digits of s pass the Luhn checksum
+func luhnValid(s string) bool {
+	sum, n := 0, 0
+	for i := len(s) - 1; i >= 0; i-- {
+		c := s[i]
+		if c < '0' || c > '9' {
+			continue
+		}
+		d := int(c - '0')
+		if n%2 == 1 {
+			d *= 2
+			if d > 9 {
+				d -= 9
+			}
+		}
+		sum += d
+		n++
+	}
+	return n >= 13 && sum%10 == 0
+}
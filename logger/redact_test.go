@@ -0,0 +1,209 @@
+package logger_test
+
+import (
+	"net/http"
+	"regexp"
+	"testing"
+
+	"github.com/funxdofficial/golang-module-syslog/logger"
+)
+
+func TestRedactString(t *testing.T) {
+	redactor := logger.NewRedactor(logger.RedactConfig{})
+
+	tests := []struct {
+		name string
+		in   string
+		want string
+	}{
+		{"key=value", "login password=hunter2&user=bob", "login password=[REDACTED]&user=bob"},
+		{"json key", `{"user":"bob","token":"abc"}`, `{"user":"bob","token":"[REDACTED]"}`},
+		{"header style", "upstream rejected Authorization: Bearer abc.def", "upstream rejected Authorization: [REDACTED]"},
+		{"header style per line", "Cookie: sid=1\nHost: api", "Cookie: [REDACTED]\nHost: api"},
+		{"header in deny-list only", "X-Api-Key: k-123", "X-Api-Key: [REDACTED]"},
+		{"pan with luhn", "card 4111 1111 1111 1111 ok", "card ***************1111 ok"},
+		{"pan without luhn", "ref 4111111111111112", "ref [REDACTED]"},
+		{"email", "sent to bob@example.com", "sent to [REDACTED]"},
+		{"phone with country code", "call +62 812-3456-7890", "call *************7890"},
+		{"local number is not a phone", "order 0123456789 created", "order 0123456789 created"},
+		{"national id", "nik 3201234567890001", "nik [REDACTED]"},
+		{"plain text", "nothing to hide", "nothing to hide"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := redactor.RedactString(tt.in); got != tt.want {
+				t.Errorf("RedactString(%q) = %q, want %q", tt.in, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRedactBody(t *testing.T) {
+	redactor := logger.NewRedactor(logger.RedactConfig{
+		Paths: []string{"$.customer.address", "items[*].card.number", "meta.*.secret_note"},
+	})
+
+	tests := []struct {
+		name string
+		in   string
+		want string
+	}{
+		{
+			"nested key",
+			`{"user":{"name":"bob","password":"x"}}`,
+			`{"user":{"name":"bob","password":"[REDACTED]"}}`,
+		},
+		{
+			"path",
+			`{"customer":{"name":"bob","address":{"city":"Jakarta"}}}`,
+			`{"customer":{"name":"bob","address":"[REDACTED]"}}`,
+		},
+		{
+			"path through array",
+			`{"items":[{"card":{"number":"1234","brand":"visa"}}]}`,
+			`{"items":[{"card":{"number":"[REDACTED]","brand":"visa"}}]}`,
+		},
+		{
+			"wildcard path",
+			`{"meta":{"a":{"secret_note":"x","ok":1}}}`,
+			`{"meta":{"a":{"secret_note":"[REDACTED]","ok":1}}}`,
+		},
+		{
+			"header name as json key",
+			`{"Cookie":"sid=1","path":"/"}`,
+			`{"Cookie":"[REDACTED]","path":"/"}`,
+		},
+		{
+			"rules inside json strings",
+			`{"note":"mail bob@example.com"}`,
+			`{"note":"mail [REDACTED]"}`,
+		},
+		{
+			"card number as json number",
+			`{"pan":4111111111111111}`,
+			`{"pan":"************1111"}`,
+		},
+		{
+			"truncated json",
+			`{"user":"bob","password":"hunter2","tok`,
+			`{"user":"bob","password":"[REDACTED]","tok`,
+		},
+		{
+			"truncated inside value",
+			`{"user":"bob","password":"hunt`,
+			`{"user":"bob","password":"[REDACTED]"`,
+		},
+		{
+			"form body",
+			"user=bob&password=hunter2",
+			"user=bob&password=[REDACTED]",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := redactor.RedactBody(tt.in); got != tt.want {
+				t.Errorf("RedactBody(%q) =\n%s\nwant\n%s", tt.in, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRedactStrategies(t *testing.T) {
+	tests := []struct {
+		strategy logger.RedactStrategy
+		want     string
+	}{
+		{logger.RedactFull, "password=[REDACTED]"},
+		{"", "password=[REDACTED]"},
+		{logger.RedactLast4, "password=****5678"},
+		{logger.RedactHash, "password=sha256:ef797c8118f02dfb"},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.strategy), func(t *testing.T) {
+			redactor := logger.NewRedactor(logger.RedactConfig{Strategy: tt.strategy})
+			if got := redactor.RedactString("password=12345678"); got != tt.want {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+
+	if got := logger.NewRedactor(logger.RedactConfig{Strategy: logger.RedactLast4}).RedactString("pin=12"); got != "pin=**" {
+		t.Errorf("short value: got %q", got)
+	}
+}
+
+func TestRedactLuhn(t *testing.T) {
+	redactor := logger.NewRedactor(logger.RedactConfig{Keys: []string{}, Rules: []logger.RedactRule{logger.RulePAN}})
+
+	tests := []struct {
+		in     string
+		masked bool
+	}{
+		{"4111111111111111", true},      // Visa test card
+		{"5500-0000-0000-0004", true},   // Mastercard, dipisah "-"
+		{"378282246310005", true},       // Amex 15 digit
+		{"4111111111111112", false},     // Checksum salah
+		{"1234567890123", false},        // 13 digit, checksum salah
+		{"411111111111", false},         // Terlalu pendek
+		{"41111111111111111111", false}, // 20 digit
+	}
+	for _, tt := range tests {
+		got := redactor.RedactString(tt.in)
+		if masked := got != tt.in; masked != tt.masked {
+			t.Errorf("RedactString(%q) = %q, masked = %v, want %v", tt.in, got, masked, tt.masked)
+		}
+	}
+}
+
+func TestRedactCustomRuleAndValidate(t *testing.T) {
+	redactor := logger.NewRedactor(logger.RedactConfig{Rules: []logger.RedactRule{{
+		Name:     "order",
+		Pattern:  regexp.MustCompile(`ORD-\d+`),
+		Strategy: logger.RedactLast4,
+		Validate: func(match string) bool { return match != "ORD-0" },
+	}}})
+
+	if got := redactor.RedactString("ORD-123456 and ORD-0"); got != "******3456 and ORD-0" {
+		t.Errorf("got %q", got)
+	}
+}
+
+func TestRedactFieldsAndHeaders(t *testing.T) {
+	redactor := logger.NewRedactor(logger.RedactConfig{})
+
+	fields := []logger.Field{
+		logger.String("user", "bob"),
+		logger.String("Authorization", "Bearer abc"),
+		logger.String("set-cookie", "sid=1"),
+		logger.String("note", "mail bob@example.com"),
+	}
+	got := redactor.RedactFields(fields)
+	want := []string{"bob", "[REDACTED]", "[REDACTED]", "mail [REDACTED]"}
+	for i, f := range got {
+		if f.ValueString() != want[i] {
+			t.Errorf("field %s = %q, want %q", f.Key, f.ValueString(), want[i])
+		}
+	}
+	if fields[1].ValueString() != "Bearer abc" {
+		t.Error("RedactFields modified the original slice")
+	}
+
+	h := http.Header{"Authorization": {"Bearer abc"}, "Accept": {"*/*"}}
+	redacted := redactor.RedactHeaders(h)
+	if redacted.Get("Authorization") != "[REDACTED]" || redacted.Get("Accept") != "*/*" {
+		t.Errorf("RedactHeaders = %v", redacted)
+	}
+	if h.Get("Authorization") != "Bearer abc" {
+		t.Error("RedactHeaders modified the original header")
+	}
+}
+
+func TestRedactNilRedactor(t *testing.T) {
+	var redactor *logger.Redactor
+	if got := redactor.RedactString("password=x"); got != "password=x" {
+		t.Errorf("nil redactor changed %q", got)
+	}
+	if got := redactor.RedactBody(`{"password":"x"}`); got != `{"password":"x"}` {
+		t.Errorf("nil redactor changed %q", got)
+	}
+}
@@ -0,0 +1,54 @@
+package logger
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+)
+
+// Reopener is implemented by sinks that can reopen their output (misalnya FileSink)
+type Reopener interface {
+	Reopen() error
+}
+
+// Reopen reopens every file sink, untuk logrotate eksternal yang me-rename file log.
+// Reopen dijalankan oleh worker di antara dua entry, sehingga tidak ada baris yang
+// setengah ditulis ke file lama dan setengah ke file baru.
+func (l *Logger) Reopen() error {
+	return l.barrier(context.Background(), &logMessage{flushed: make(chan struct{}), reopen: true})
+}
+
+// reopenSinks reopens every sink that implements Reopener, returning the first error
+func (l *Logger) reopenSinks() error {
+	var err error
+	for _, s := range l.sinks {
+		if reopener, ok := s.sink.(Reopener); ok {
+			if reopenErr := reopener.Reopen(); err == nil {
+				err = reopenErr
+			}
+		}
+	}
+	return err
+}
+
+// reopenOnSIGHUP calls Reopen every time SIGHUP is received, sampai logger di-close
+func (l *Logger) reopenOnSIGHUP() {
+	ch := make(chan os.Signal, 1)
+	signal.Notify(ch, syscall.SIGHUP)
+
+	go func() {
+		defer signal.Stop(ch)
+		for {
+			select {
+			case <-ch:
+				if err := l.Reopen(); err != nil {
+					fmt.Fprintf(os.Stderr, "[LOGGER ERROR] Failed to reopen log file: %v\n", err)
+				}
+			case <-l.closed:
+				return
+			}
+		}
+	}()
+}
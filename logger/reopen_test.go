@@ -0,0 +1,139 @@
+package logger_test
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/funxdofficial/golang-module-syslog/logger"
+)
+
+// newFileLogger starts a logger that writes logfmt to dir/app.log
+func newFileLogger(t *testing.T, mode logger.LogMode) (*logger.Logger, string) {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "app.log")
+	appLogger, err := logger.StartLogger(&logger.LoggerConfig{
+		Type:                   logger.LogTypeFile,
+		LogFile:                path,
+		Format:                 logger.FormatLogfmt,
+		Mode:                   mode,
+		OverflowPolicy:         logger.OverflowBlock,
+		OverflowReportInterval: -1,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { appLogger.Close() })
+	return appLogger, path
+}
+
+func readLog(t *testing.T, path string) string {
+	t.Helper()
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(data)
+}
+
+func TestLoggerReopenAfterRename(t *testing.T) {
+	for _, mode := range []logger.LogMode{logger.ModeAsync, logger.ModeSync} {
+		t.Run(string(mode), func(t *testing.T) {
+			appLogger, path := newFileLogger(t, mode)
+			rotated := path + ".1"
+
+			appLogger.Info("before rename")
+			// logrotate (mode create) me-rename file; entry berikutnya masih ke file lama sampai Reopen
+			if err := os.Rename(path, rotated); err != nil {
+				t.Fatal(err)
+			}
+			appLogger.Info("before reopen")
+			if err := appLogger.Reopen(); err != nil {
+				t.Fatalf("Reopen: %v", err)
+			}
+			appLogger.Info("after reopen")
+			appLogger.Close()
+
+			old, current := readLog(t, rotated), readLog(t, path)
+			for _, msg := range []string{"before rename", "before reopen"} {
+				if !strings.Contains(old, msg) || strings.Contains(current, msg) {
+					t.Errorf("%q must be only in the rotated file\nrotated:\n%s\ncurrent:\n%s", msg, old, current)
+				}
+			}
+			if !strings.Contains(current, "after reopen") || strings.Contains(old, "after reopen") {
+				t.Errorf("%q must be only in the new file\nrotated:\n%s\ncurrent:\n%s", "after reopen", old, current)
+			}
+		})
+	}
+}
+
+func TestLoggerReopenConcurrentWrites(t *testing.T) {
+	appLogger, path := newFileLogger(t, logger.ModeAsync)
+
+	const writers, perWriter = 4, 200
+	var wg sync.WaitGroup
+	for w := 0; w < writers; w++ {
+		wg.Add(1)
+		go func(w int) {
+			defer wg.Done()
+			for i := 0; i < perWriter; i++ {
+				appLogger.Info("writer %d entry %d", w, i)
+			}
+		}(w)
+	}
+
+	// Rename dan Reopen berulang kali selagi writer berjalan
+	for i := 1; i <= 5; i++ {
+		if err := os.Rename(path, fmt.Sprintf("%s.%d", path, i)); err != nil {
+			t.Fatal(err)
+		}
+		if err := appLogger.Reopen(); err != nil {
+			t.Fatalf("Reopen: %v", err)
+		}
+	}
+	wg.Wait()
+	appLogger.Close()
+
+	// Setiap baris lengkap di salah satu file: tidak ada yang hilang atau terpotong di batas reopen
+	files, _ := filepath.Glob(path + "*")
+	lines := 0
+	for _, f := range files {
+		for _, line := range strings.Split(strings.TrimSuffix(readLog(t, f), "\n"), "\n") {
+			if line == "" {
+				continue
+			}
+			if !strings.HasPrefix(line, "timestamp=") || !strings.Contains(line, "function=") {
+				t.Errorf("incomplete line in %s: %q", filepath.Base(f), line)
+			}
+			lines++
+		}
+	}
+	if lines != writers*perWriter {
+		t.Errorf("got %d lines across %d files, want %d", lines, len(files), writers*perWriter)
+	}
+}
+
+func TestLoggerReopenFailureKeepsOldFile(t *testing.T) {
+	appLogger, path := newFileLogger(t, logger.ModeAsync)
+	rotated := path + ".1"
+
+	if err := os.Rename(path, rotated); err != nil {
+		t.Fatal(err)
+	}
+	// Path tidak bisa dibuka sebagai file
+	if err := os.Mkdir(path, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := appLogger.Reopen(); err == nil {
+		t.Fatal("Reopen succeeded, want an error when the path cannot be opened")
+	}
+
+	appLogger.Info("after failed reopen")
+	appLogger.Close()
+	if !strings.Contains(readLog(t, rotated), "after failed reopen") {
+		t.Error("entries after a failed Reopen must still be written to the old file")
+	}
+}
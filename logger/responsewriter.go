@@ -0,0 +1,259 @@
+package logger
+
+import (
+	"bufio"
+	"io"
+	"net"
+	"net/http"
+	"time"
+)
+
+// ResponseWriter is the http.ResponseWriter given to handlers by StandardHTTPMiddleware.
+// Interface opsional dari writer asli (http.Flusher, http.Hijacker, http.Pusher, io.ReaderFrom)
+// tetap tersedia, dan Unwrap membuat http.ResponseController bekerja.
+type ResponseWriter interface {
+	http.ResponseWriter
+	Status() int         // Status response, 200 jika handler tidak memanggil WriteHeader (0 jika di-hijack tanpa status)
+	BytesWritten() int64 // Jumlah byte body yang ditulis
+	TTFB() time.Duration // Waktu sampai header / byte pertama dikirim (0 jika belum)
+	Hijacked() bool      // True jika koneksi di-hijack (misalnya websocket)
+	Written() bool       // True jika header sudah dikirim atau koneksi di-hijack
+	Unwrap() http.ResponseWriter
+}
+
+// WrapResponseWriter wraps w to record status, bytes, TTFB dan hijack, dan mengisi capture
+// (boleh nil) dengan response body. Berguna untuk middleware framework lain.
+func WrapResponseWriter(w http.ResponseWriter, capture *BodyCapture) ResponseWriter {
+	return wrapResponseWriter(w, capture, systemClock{})
+}
+
+// wrapResponseWriter is WrapResponseWriter with the Clock of a logger untuk TTFB
+func wrapResponseWriter(w http.ResponseWriter, capture *BodyCapture, clock Clock) ResponseWriter {
+	rw := &responseWriter{ResponseWriter: w, body: capture, clock: clock, start: clock.Now()}
+
+	// Hanya interface yang didukung writer asli yang di-expose
+	flusher, isFlusher := w.(http.Flusher)
+	hijacker, isHijacker := w.(http.Hijacker)
+	pusher, isPusher := w.(http.Pusher)
+	readerFrom, isReaderFrom := w.(io.ReaderFrom)
+	f := rwFlusher{rw, flusher}
+	h := rwHijacker{rw, hijacker}
+	p := rwPusher{pusher}
+	rf := rwReaderFrom{rw, readerFrom}
+
+	switch {
+	case isFlusher && isHijacker && isPusher && isReaderFrom:
+		return struct {
+			*responseWriter
+			http.Flusher
+			http.Hijacker
+			http.Pusher
+			io.ReaderFrom
+		}{rw, f, h, p, rf}
+	case isFlusher && isHijacker && isPusher:
+		return struct {
+			*responseWriter
+			http.Flusher
+			http.Hijacker
+			http.Pusher
+		}{rw, f, h, p}
+	case isFlusher && isHijacker && isReaderFrom:
+		return struct {
+			*responseWriter
+			http.Flusher
+			http.Hijacker
+			io.ReaderFrom
+		}{rw, f, h, rf}
+	case isFlusher && isPusher && isReaderFrom:
+		return struct {
+			*responseWriter
+			http.Flusher
+			http.Pusher
+			io.ReaderFrom
+		}{rw, f, p, rf}
+	case isHijacker && isPusher && isReaderFrom:
+		return struct {
+			*responseWriter
+			http.Hijacker
+			http.Pusher
+			io.ReaderFrom
+		}{rw, h, p, rf}
+	case isFlusher && isHijacker:
+		return struct {
+			*responseWriter
+			http.Flusher
+			http.Hijacker
+		}{rw, f, h}
+	case isFlusher && isPusher:
+		return struct {
+			*responseWriter
+			http.Flusher
+			http.Pusher
+		}{rw, f, p}
+	case isFlusher && isReaderFrom:
+		return struct {
+			*responseWriter
+			http.Flusher
+			io.ReaderFrom
+		}{rw, f, rf}
+	case isHijacker && isPusher:
+		return struct {
+			*responseWriter
+			http.Hijacker
+			http.Pusher
+		}{rw, h, p}
+	case isHijacker && isReaderFrom:
+		return struct {
+			*responseWriter
+			http.Hijacker
+			io.ReaderFrom
+		}{rw, h, rf}
+	case isPusher && isReaderFrom:
+		return struct {
+			*responseWriter
+			http.Pusher
+			io.ReaderFrom
+		}{rw, p, rf}
+	case isFlusher:
+		return struct {
+			*responseWriter
+			http.Flusher
+		}{rw, f}
+	case isHijacker:
+		return struct {
+			*responseWriter
+			http.Hijacker
+		}{rw, h}
+	case isPusher:
+		return struct {
+			*responseWriter
+			http.Pusher
+		}{rw, p}
+	case isReaderFrom:
+		return struct {
+			*responseWriter
+			io.ReaderFrom
+		}{rw, rf}
+	}
+	return rw
+}
+
+// responseWriter records status, bytes, TTFB and the response body
+type responseWriter struct {
+	http.ResponseWriter
+	body       *BodyCapture
+	clock      Clock
+	start      time.Time
+	statusCode int
+	bytes      int64
+	ttfb       time.Duration
+	hijacked   bool
+}
+
+func (rw *responseWriter) WriteHeader(code int) {
+	// Status 1xx (misalnya 103 Early Hints) bukan status final, kecuali 101 Switching Protocols
+	if rw.statusCode == 0 && (code >= 200 || code == http.StatusSwitchingProtocols) {
+		rw.statusCode = code
+		rw.firstByte()
+	}
+	rw.ResponseWriter.WriteHeader(code)
+}
+
+func (rw *responseWriter) Write(b []byte) (int, error) {
+	rw.implicitHeader()
+	n, err := rw.ResponseWriter.Write(b)
+	rw.bytes += int64(n)
+	rw.body.Capture(rw.Header(), b[:n])
+	return n, err
+}
+
+// implicitHeader records the implicit 200 written by net/http on the first Write
+func (rw *responseWriter) implicitHeader() {
+	if rw.statusCode == 0 {
+		rw.statusCode = http.StatusOK
+		rw.firstByte()
+	}
+}
+
+// firstByte records the time to first byte
+func (rw *responseWriter) firstByte() {
+	if rw.ttfb == 0 {
+		rw.ttfb = rw.clock.Now().Sub(rw.start)
+	}
+}
+
+func (rw *responseWriter) Status() int {
+	if rw.statusCode == 0 && !rw.hijacked {
+		return http.StatusOK
+	}
+	return rw.statusCode
+}
+
+func (rw *responseWriter) BytesWritten() int64 {
+	return rw.bytes
+}
+
+func (rw *responseWriter) TTFB() time.Duration {
+	return rw.ttfb
+}
+
+func (rw *responseWriter) Hijacked() bool {
+	return rw.hijacked
+}
+
+func (rw *responseWriter) Written() bool {
+	return rw.statusCode != 0 || rw.hijacked
+}
+
+func (rw *responseWriter) Unwrap() http.ResponseWriter {
+	return rw.ResponseWriter
+}
+
+// rwFlusher forwards http.Flusher
+type rwFlusher struct {
+	rw *responseWriter
+	f  http.Flusher
+}
+
+func (f rwFlusher) Flush() {
+	f.rw.implicitHeader()
+	f.f.Flush()
+}
+
+// rwHijacker forwards http.Hijacker
+type rwHijacker struct {
+	rw *responseWriter
+	h  http.Hijacker
+}
+
+func (h rwHijacker) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	conn, buf, err := h.h.Hijack()
+	if err == nil {
+		h.rw.hijacked = true
+		h.rw.firstByte()
+	}
+	return conn, buf, err
+}
+
+// rwPusher forwards http.Pusher
+type rwPusher struct {
+	p http.Pusher
+}
+
+func (p rwPusher) Push(target string, opts *http.PushOptions) error {
+	return p.p.Push(target, opts)
+}
+
+// rwReaderFrom forwards io.ReaderFrom. Body dari ReadFrom (misalnya io.Copy dari file)
+// tidak di-capture agar sendfile tetap dipakai, tapi jumlah byte tetap dihitung.
+type rwReaderFrom struct {
+	rw *responseWriter
+	rf io.ReaderFrom
+}
+
+func (rf rwReaderFrom) ReadFrom(src io.Reader) (int64, error) {
+	rf.rw.implicitHeader()
+	n, err := rf.rf.ReadFrom(src)
+	rf.rw.bytes += n
+	return n, err
+}
@@ -0,0 +1,290 @@
+package logger_test
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/funxdofficial/golang-module-syslog/logger"
+	"github.com/funxdofficial/golang-module-syslog/logger/logtest"
+)
+
+// baseWriter is an http.ResponseWriter that records calls to the optional interfaces
+type baseWriter struct {
+	*httptest.ResponseRecorder
+	calls []string
+}
+
+func (w *baseWriter) flush() { w.calls = append(w.calls, "flush"); w.ResponseRecorder.Flush() }
+
+func (w *baseWriter) hijack() (net.Conn, *bufio.ReadWriter, error) {
+	w.calls = append(w.calls, "hijack")
+	server, client := net.Pipe()
+	client.Close()
+	return server, nil, nil
+}
+
+func (w *baseWriter) push(string, *http.PushOptions) error {
+	w.calls = append(w.calls, "push")
+	return nil
+}
+
+func (w *baseWriter) readFrom(src io.Reader) (int64, error) {
+	w.calls = append(w.calls, "readfrom")
+	return io.Copy(w.ResponseRecorder.Body, src)
+}
+
+type flusherOnly struct{ *baseWriter }
+
+func (w flusherOnly) Flush() { w.flush() }
+
+type hijackerOnly struct{ *baseWriter }
+
+func (w hijackerOnly) Hijack() (net.Conn, *bufio.ReadWriter, error) { return w.hijack() }
+
+type pusherOnly struct{ *baseWriter }
+
+func (w pusherOnly) Push(t string, o *http.PushOptions) error { return w.push(t, o) }
+
+type readerFromOnly struct{ *baseWriter }
+
+func (w readerFromOnly) ReadFrom(src io.Reader) (int64, error) { return w.readFrom(src) }
+
+// newBaseWriter returns a writer implementing exactly the optional interfaces in mask
+// (bit 0 Flusher, bit 1 Hijacker, bit 2 Pusher, bit 3 ReaderFrom)
+func newBaseWriter(mask int) (http.ResponseWriter, *baseWriter) {
+	base := &baseWriter{ResponseRecorder: httptest.NewRecorder()}
+	return compose(base, mask), base
+}
+
+// compose builds a struct type with only the selected methods
+func compose(base *baseWriter, mask int) http.ResponseWriter {
+	type rw = http.ResponseWriter
+	f, h, p, r := flusherOnly{base}, hijackerOnly{base}, pusherOnly{base}, readerFromOnly{base}
+	switch mask {
+	case 0:
+		return struct{ rw }{base}
+	case 1:
+		return struct {
+			rw
+			http.Flusher
+		}{base, f}
+	case 2:
+		return struct {
+			rw
+			http.Hijacker
+		}{base, h}
+	case 3:
+		return struct {
+			rw
+			http.Flusher
+			http.Hijacker
+		}{base, f, h}
+	case 4:
+		return struct {
+			rw
+			http.Pusher
+		}{base, p}
+	case 5:
+		return struct {
+			rw
+			http.Flusher
+			http.Pusher
+		}{base, f, p}
+	case 6:
+		return struct {
+			rw
+			http.Hijacker
+			http.Pusher
+		}{base, h, p}
+	case 7:
+		return struct {
+			rw
+			http.Flusher
+			http.Hijacker
+			http.Pusher
+		}{base, f, h, p}
+	case 8:
+		return struct {
+			rw
+			io.ReaderFrom
+		}{base, r}
+	case 9:
+		return struct {
+			rw
+			http.Flusher
+			io.ReaderFrom
+		}{base, f, r}
+	case 10:
+		return struct {
+			rw
+			http.Hijacker
+			io.ReaderFrom
+		}{base, h, r}
+	case 11:
+		return struct {
+			rw
+			http.Flusher
+			http.Hijacker
+			io.ReaderFrom
+		}{base, f, h, r}
+	case 12:
+		return struct {
+			rw
+			http.Pusher
+			io.ReaderFrom
+		}{base, p, r}
+	case 13:
+		return struct {
+			rw
+			http.Flusher
+			http.Pusher
+			io.ReaderFrom
+		}{base, f, p, r}
+	case 14:
+		return struct {
+			rw
+			http.Hijacker
+			http.Pusher
+			io.ReaderFrom
+		}{base, h, p, r}
+	}
+	return struct {
+		rw
+		http.Flusher
+		http.Hijacker
+		http.Pusher
+		io.ReaderFrom
+	}{base, f, h, p, r}
+}
+
+func TestWrapResponseWriterInterfaceMatrix(t *testing.T) {
+	for mask := 0; mask < 16; mask++ {
+		t.Run(fmt.Sprintf("mask=%04b", mask), func(t *testing.T) {
+			w, base := newBaseWriter(mask)
+			wrapped := logger.WrapResponseWriter(w, nil)
+
+			_, isFlusher := wrapped.(http.Flusher)
+			_, isHijacker := wrapped.(http.Hijacker)
+			_, isPusher := wrapped.(http.Pusher)
+			_, isReaderFrom := wrapped.(io.ReaderFrom)
+			got := [4]bool{isFlusher, isHijacker, isPusher, isReaderFrom}
+			want := [4]bool{mask&1 != 0, mask&2 != 0, mask&4 != 0, mask&8 != 0}
+			if got != want {
+				t.Fatalf("interfaces = %v, want %v", got, want)
+			}
+			if wrapped.Unwrap() != w {
+				t.Error("Unwrap does not return the original writer")
+			}
+
+			// Setiap interface diteruskan ke writer asli dan tetap mencatat status / bytes
+			var wantCalls []string
+			if isFlusher {
+				wrapped.(http.Flusher).Flush()
+				wantCalls = append(wantCalls, "flush")
+				if wrapped.Status() != http.StatusOK || !wrapped.Written() {
+					t.Errorf("Flush: status = %d, written = %v", wrapped.Status(), wrapped.Written())
+				}
+			}
+			if isPusher {
+				wrapped.(http.Pusher).Push("/app.js", nil)
+				wantCalls = append(wantCalls, "push")
+			}
+			if isReaderFrom {
+				n, _ := wrapped.(io.ReaderFrom).ReadFrom(strings.NewReader("hello"))
+				wantCalls = append(wantCalls, "readfrom")
+				if n != 5 || wrapped.BytesWritten() != 5 {
+					t.Errorf("ReadFrom: n = %d, bytes = %d", n, wrapped.BytesWritten())
+				}
+			}
+			if isHijacker {
+				conn, _, err := wrapped.(http.Hijacker).Hijack()
+				if err != nil {
+					t.Fatal(err)
+				}
+				conn.Close()
+				wantCalls = append(wantCalls, "hijack")
+				if !wrapped.Hijacked() || !wrapped.Written() {
+					t.Error("Hijack not recorded")
+				}
+			}
+			if strings.Join(base.calls, ",") != strings.Join(wantCalls, ",") {
+				t.Errorf("calls = %v, want %v", base.calls, wantCalls)
+			}
+		})
+	}
+}
+
+func TestWrapResponseWriterStatusAndBytes(t *testing.T) {
+	rec := httptest.NewRecorder()
+	capture := logger.MiddlewareConfig{MaxBodyBytes: 4}.NewResponseCapture("/")
+	wrapped := logger.WrapResponseWriter(rec, capture)
+
+	if wrapped.Written() {
+		t.Fatal("fresh writer reports a written response")
+	}
+	wrapped.WriteHeader(http.StatusCreated)
+	wrapped.WriteHeader(http.StatusInternalServerError) // Diabaikan, header sudah dikirim
+	wrapped.Write([]byte("hello "))
+	wrapped.Write([]byte("world"))
+
+	if wrapped.Status() != http.StatusCreated {
+		t.Errorf("Status = %d, want 201", wrapped.Status())
+	}
+	if wrapped.BytesWritten() != 11 || rec.Body.String() != "hello world" {
+		t.Errorf("BytesWritten = %d, body = %q", wrapped.BytesWritten(), rec.Body.String())
+	}
+	if capture.String() != "hell...(truncated)" {
+		t.Errorf("capture = %q", capture.String())
+	}
+}
+
+func TestMiddlewareLogsStatusOfHijackedAndStreamedResponses(t *testing.T) {
+	appLogger, logs := logtest.New(t)
+
+	handler := appLogger.StandardHTTPMiddleware(logger.MiddlewareConfig{})(
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "text/event-stream")
+			io.WriteString(w, "data: 1\n\n")
+			w.(http.Flusher).Flush()
+			io.WriteString(w, "data: 2\n\n")
+		}))
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("GET", "/events", nil))
+
+	stop := logs.All().Flag(logger.FlagStop)
+	if len(stop) != 1 {
+		t.Fatalf("got %d STOP entries", len(stop))
+	}
+	if len(stop.Field("status", 200).Field("bytes", 18)) != 1 {
+		t.Errorf("STOP fields = %v", stop[0].Fields)
+	}
+	if stop[0].Body != "" {
+		t.Errorf("event stream body captured: %q", stop[0].Body)
+	}
+}
+
+func TestWrapResponseWriterIgnoresInformationalStatus(t *testing.T) {
+	var status int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		wrapped := logger.WrapResponseWriter(w, nil)
+		wrapped.Header().Set("Link", "</app.js>; rel=preload")
+		wrapped.WriteHeader(http.StatusEarlyHints)
+		wrapped.WriteHeader(http.StatusAccepted)
+		status = wrapped.Status()
+	}))
+	defer server.Close()
+
+	resp, err := http.Get(server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if status != http.StatusAccepted || resp.StatusCode != http.StatusAccepted {
+		t.Errorf("Status = %d, response = %d, want 202", status, resp.StatusCode)
+	}
+}
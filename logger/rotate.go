@@ -0,0 +1,288 @@
+package logger
+
+import (
+	"compress/gzip"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// RotateInterval represents the schedule of time-based log rotation
+type RotateInterval string
+
+const (
+	RotateNone   RotateInterval = ""       // Tidak ada rotasi terjadwal
+	RotateHourly RotateInterval = "hourly" // Rotasi setiap pergantian jam
+	RotateDaily  RotateInterval = "daily"  // Rotasi setiap tengah malam (waktu lokal)
+)
+
+// backupTimeLayout is the timestamp used in rotated file names (app-2006-01-02T15-04-05.000.log)
+const backupTimeLayout = "2006-01-02T15-04-05.000"
+
+// fileWriter writes to the log file and rotates it by size and/or schedule.
+// Write hanya dipanggil dari worker goroutine, sehingga rotasi selalu terjadi di antara
+// dua baris log: tidak ada baris yang hilang atau terpotong di batas rotasi.
+type fileWriter struct {
+	path       string
+	file       *os.File
+	size       int64
+	maxSize    int64
+	interval   RotateInterval
+	nextRotate time.Time
+	maxBackups int
+	maxAge     time.Duration
+	compress   bool
+	clock      Clock
+
+	// Kompresi dan retention berjalan di background agar worker tidak terhambat
+	millMu sync.Mutex
+	millWg sync.WaitGroup
+}
+
+// newFileWriter opens the log file in append mode with the rotation settings from config
+func newFileWriter(config FileSinkConfig) (*fileWriter, error) {
+	switch config.RotateInterval {
+	case RotateNone, RotateHourly, RotateDaily:
+	default:
+		return nil, fmt.Errorf("unsupported RotateInterval '%s'", config.RotateInterval)
+	}
+
+	w := &fileWriter{
+		path:       config.Path,
+		maxSize:    int64(config.MaxSizeMB) * 1024 * 1024,
+		interval:   config.RotateInterval,
+		maxBackups: config.MaxBackups,
+		maxAge:     time.Duration(config.MaxAgeDays) * 24 * time.Hour,
+		compress:   config.Compress,
+		clock:      config.Clock,
+	}
+	if w.clock == nil {
+		w.clock = systemClock{}
+	}
+	if err := w.open(); err != nil {
+		return nil, err
+	}
+	return w, nil
+}
+
+// open opens (or creates) the log file and resets size and schedule
+func (w *fileWriter) open() error {
+	file, err := os.OpenFile(w.path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0666)
+	if err != nil {
+		return err
+	}
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return err
+	}
+
+	w.file = file
+	w.size = info.Size()
+
+	// File lama yang dibuat di periode sebelumnya akan di-rotate pada write pertama
+	since := w.clock.Now()
+	if w.size > 0 {
+		since = info.ModTime()
+	}
+	w.nextRotate = nextRotation(w.interval, since)
+	return nil
+}
+
+// Write writes one complete log line, rotating the file first if needed
+func (w *fileWriter) Write(p []byte) (int, error) {
+	if w.shouldRotate(int64(len(p))) {
+		if err := w.rotate(); err != nil {
+			fmt.Fprintf(os.Stderr, "[LOGGER ERROR] Failed to rotate log file: %v\n", err)
+		}
+	}
+	if w.file == nil {
+		return 0, fmt.Errorf("log file is closed")
+	}
+
+	n, err := w.file.Write(p)
+	w.size += int64(n)
+	return n, err
+}
+
+// shouldRotate reports whether writing n more bytes requires a rotation
+func (w *fileWriter) shouldRotate(n int64) bool {
+	if w.maxSize > 0 && w.size > 0 && w.size+n > w.maxSize {
+		return true
+	}
+	return !w.nextRotate.IsZero() && !w.clock.Now().Before(w.nextRotate)
+}
+
+// rotate renames the current file to a timestamped backup and opens a fresh one
+func (w *fileWriter) rotate() error {
+	if w.file != nil {
+		if err := w.file.Close(); err != nil {
+			return err
+		}
+		w.file = nil
+	}
+
+	if err := os.Rename(w.path, w.backupName(w.clock.Now())); err != nil && !os.IsNotExist(err) {
+		// Tetap buka file lama agar log tidak hilang
+		w.open()
+		return err
+	}
+	if err := w.open(); err != nil {
+		return err
+	}
+
+	w.millWg.Add(1)
+	go w.mill()
+	return nil
+}
+
+// Reopen opens the log file path again and closes the previous file, untuk logrotate eksternal
+// yang me-rename file (mode create). Jika open gagal, file lama tetap dipakai.
+func (w *fileWriter) Reopen() error {
+	old := w.file
+	if err := w.open(); err != nil {
+		return err
+	}
+	if old != nil {
+		return old.Close()
+	}
+	return nil
+}
+
+// backupName returns the rotated file name, e.g. app-2006-01-02T15-04-05.000.log
+func (w *fileWriter) backupName(t time.Time) string {
+	dir := filepath.Dir(w.path)
+	ext := filepath.Ext(w.path)
+	prefix := strings.TrimSuffix(filepath.Base(w.path), ext)
+	// Waktu lokal, sama dengan timezone yang dipakai backups() untuk parse nama file
+	return filepath.Join(dir, fmt.Sprintf("%s-%s%s", prefix, t.Local().Format(backupTimeLayout), ext))
+}
+
+// backup is a rotated log file found on disk
+type backup struct {
+	path string
+	time time.Time
+}
+
+// backups lists the rotated files of this log, newest first
+func (w *fileWriter) backups() ([]backup, error) {
+	dir := filepath.Dir(w.path)
+	ext := filepath.Ext(w.path)
+	prefix := strings.TrimSuffix(filepath.Base(w.path), ext) + "-"
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	var result []backup
+	for _, e := range entries {
+		name := e.Name()
+		if e.IsDir() || !strings.HasPrefix(name, prefix) {
+			continue
+		}
+		stamp := strings.TrimSuffix(strings.TrimSuffix(name, ".gz"), ext)
+		t, err := time.ParseInLocation(backupTimeLayout, strings.TrimPrefix(stamp, prefix), time.Local)
+		if err != nil {
+			continue
+		}
+		result = append(result, backup{path: filepath.Join(dir, name), time: t})
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].time.After(result[j].time) })
+	return result, nil
+}
+
+// mill compresses rotated files and applies MaxBackups / MaxAgeDays retention
+func (w *fileWriter) mill() {
+	defer w.millWg.Done()
+	w.millMu.Lock()
+	defer w.millMu.Unlock()
+
+	files, err := w.backups()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "[LOGGER ERROR] Failed to list rotated log files: %v\n", err)
+		return
+	}
+
+	cutoff := w.clock.Now().Add(-w.maxAge)
+	for i, b := range files {
+		expired := (w.maxBackups > 0 && i >= w.maxBackups) || (w.maxAge > 0 && b.time.Before(cutoff))
+		if expired {
+			if err := os.Remove(b.path); err != nil && !os.IsNotExist(err) {
+				fmt.Fprintf(os.Stderr, "[LOGGER ERROR] Failed to remove rotated log file: %v\n", err)
+			}
+			continue
+		}
+		if w.compress && !strings.HasSuffix(b.path, ".gz") {
+			if err := gzipFile(b.path); err != nil {
+				fmt.Fprintf(os.Stderr, "[LOGGER ERROR] Failed to compress rotated log file: %v\n", err)
+			}
+		}
+	}
+}
+
+// Sync fsyncs the current file
+func (w *fileWriter) Sync() error {
+	if w.file == nil {
+		return nil
+	}
+	return w.file.Sync()
+}
+
+// Close closes the log file and waits for pending compression/cleanup
+func (w *fileWriter) Close() error {
+	var err error
+	if w.file != nil {
+		err = w.file.Close()
+		w.file = nil
+	}
+	w.millWg.Wait()
+	return err
+}
+
+// nextRotation returns the next schedule boundary after t
+func nextRotation(interval RotateInterval, t time.Time) time.Time {
+	switch interval {
+	case RotateHourly:
+		return time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, t.Location())
+	case RotateDaily:
+		return time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, t.Location())
+	}
+	return time.Time{}
+}
+
+// gzipFile compresses path into path.gz and removes the original
+func gzipFile(path string) error {
+	src, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer src.Close()
+
+	dst, err := os.OpenFile(path+".gz", os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0666)
+	if err != nil {
+		return err
+	}
+
+	gz := gzip.NewWriter(dst)
+	if _, err := io.Copy(gz, src); err != nil {
+		gz.Close()
+		dst.Close()
+		os.Remove(path + ".gz")
+		return err
+	}
+	if err := gz.Close(); err != nil {
+		dst.Close()
+		os.Remove(path + ".gz")
+		return err
+	}
+	if err := dst.Close(); err != nil {
+		return err
+	}
+	return os.Remove(path)
+}
@@ -0,0 +1,218 @@
+package logger
+
+import (
+	"compress/gzip"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// manualClock is a Clock that only moves when advance is called
+type manualClock struct {
+	mu  sync.Mutex
+	now time.Time
+}
+
+func (c *manualClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+func (c *manualClock) advance(d time.Duration) {
+	c.mu.Lock()
+	c.now = c.now.Add(d)
+	c.mu.Unlock()
+}
+
+// newTestFileWriter opens dir/app.log with a size limit in bytes (MaxSizeMB terlalu besar untuk test)
+func newTestFileWriter(t *testing.T, config FileSinkConfig, maxSize int64) *fileWriter {
+	t.Helper()
+	if config.Path == "" {
+		config.Path = filepath.Join(t.TempDir(), "app.log")
+	}
+	if config.Clock == nil {
+		config.Clock = &manualClock{now: time.Date(2024, 6, 1, 10, 0, 0, 0, time.Local)}
+	}
+	w, err := newFileWriter(config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	w.maxSize = maxSize
+	return w
+}
+
+func TestFileWriterRotatesBySizeBetweenLines(t *testing.T) {
+	w := newTestFileWriter(t, FileSinkConfig{}, 100)
+
+	line := strings.Repeat("x", 39) + "\n"
+	for i := 0; i < 5; i++ {
+		if _, err := w.Write([]byte(line)); err != nil {
+			t.Fatal(err)
+		}
+		// Backup name memakai milidetik, majukan clock agar nama tidak sama
+		w.clock.(*manualClock).advance(time.Second)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	backups, err := w.backups()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(backups) != 2 {
+		t.Fatalf("got %d backups, want 2", len(backups))
+	}
+	for _, path := range []string{w.path, backups[0].path, backups[1].path} {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(data)%len(line) != 0 || len(data) > 100 {
+			t.Errorf("%s has %d bytes: line split at the rotation boundary", filepath.Base(path), len(data))
+		}
+	}
+}
+
+func TestFileWriterRetentionAndCompression(t *testing.T) {
+	w := newTestFileWriter(t, FileSinkConfig{MaxBackups: 2, Compress: true}, 10)
+
+	for i := 0; i < 5; i++ {
+		w.Write([]byte("0123456789\n"))
+		w.clock.(*manualClock).advance(time.Second)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	backups, err := w.backups()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(backups) != 2 {
+		t.Fatalf("got %d backups, want MaxBackups = 2", len(backups))
+	}
+	for _, b := range backups {
+		if !strings.HasSuffix(b.path, ".log.gz") {
+			t.Fatalf("backup %s is not compressed", b.path)
+		}
+		f, err := os.Open(b.path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		gz, err := gzip.NewReader(f)
+		if err != nil {
+			t.Fatal(err)
+		}
+		data, _ := io.ReadAll(gz)
+		f.Close()
+		if string(data) != "0123456789\n" {
+			t.Errorf("backup %s = %q", filepath.Base(b.path), data)
+		}
+	}
+}
+
+func TestFileWriterRotatesFileFromPreviousPeriod(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "app.log")
+	if err := os.WriteFile(path, []byte("yesterday\n"), 0666); err != nil {
+		t.Fatal(err)
+	}
+	clock := &manualClock{now: time.Date(2024, 6, 1, 10, 0, 0, 0, time.Local)}
+	yesterday := clock.now.Add(-24 * time.Hour)
+	os.Chtimes(path, yesterday, yesterday)
+
+	w := newTestFileWriter(t, FileSinkConfig{Path: path, RotateInterval: RotateDaily, Clock: clock}, 0)
+	w.Write([]byte("today\n"))
+	w.Close()
+
+	data, _ := os.ReadFile(path)
+	if string(data) != "today\n" {
+		t.Errorf("current file = %q", data)
+	}
+	backups, _ := w.backups()
+	if len(backups) != 1 {
+		t.Fatalf("got %d backups, want 1", len(backups))
+	}
+}
+
+func TestFileWriterRotationFollowsClock(t *testing.T) {
+	clock := &manualClock{now: time.Date(2024, 6, 1, 23, 59, 0, 0, time.Local)}
+	w := newTestFileWriter(t, FileSinkConfig{RotateInterval: RotateDaily, MaxAgeDays: 1, Clock: clock}, 0)
+
+	// Backup lama yang lebih tua dari MaxAgeDays menurut clock (bukan waktu sistem)
+	old := w.backupName(clock.now.Add(-48 * time.Hour))
+	if err := os.WriteFile(old, []byte("old\n"), 0666); err != nil {
+		t.Fatal(err)
+	}
+
+	w.Write([]byte("before midnight\n"))
+	clock.advance(2 * time.Minute)
+	w.Write([]byte("after midnight\n"))
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	want := filepath.Join(filepath.Dir(w.path), "app-2024-06-02T00-01-00.000.log")
+	if data, err := os.ReadFile(want); err != nil || string(data) != "before midnight\n" {
+		t.Errorf("backup %s = %q, %v", filepath.Base(want), data, err)
+	}
+	if _, err := os.Stat(old); !os.IsNotExist(err) {
+		t.Errorf("expired backup %s not removed", filepath.Base(old))
+	}
+}
+
+func TestFileWriterReopen(t *testing.T) {
+	w := newTestFileWriter(t, FileSinkConfig{}, 0)
+	w.Write([]byte("before\n"))
+
+	// logrotate (mode create) me-rename file, lalu memberi sinyal untuk reopen
+	rotated := w.path + ".1"
+	if err := os.Rename(w.path, rotated); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Reopen(); err != nil {
+		t.Fatal(err)
+	}
+	w.Write([]byte("after\n"))
+	w.Close()
+
+	if data, _ := os.ReadFile(rotated); string(data) != "before\n" {
+		t.Errorf("rotated file = %q", data)
+	}
+	if data, _ := os.ReadFile(w.path); string(data) != "after\n" {
+		t.Errorf("new file = %q", data)
+	}
+}
+
+func TestNextRotation(t *testing.T) {
+	loc := time.FixedZone("WIB", 7*60*60)
+	at := time.Date(2024, 12, 31, 23, 15, 0, 0, loc)
+
+	tests := []struct {
+		interval RotateInterval
+		want     time.Time
+	}{
+		{RotateNone, time.Time{}},
+		{RotateHourly, time.Date(2025, 1, 1, 0, 0, 0, 0, loc)},
+		{RotateDaily, time.Date(2025, 1, 1, 0, 0, 0, 0, loc)},
+	}
+	for _, tt := range tests {
+		if got := nextRotation(tt.interval, at); !got.Equal(tt.want) {
+			t.Errorf("nextRotation(%q) = %v, want %v", tt.interval, got, tt.want)
+		}
+	}
+	if got := nextRotation(RotateHourly, time.Date(2024, 6, 1, 10, 0, 0, 0, loc)); !got.Equal(time.Date(2024, 6, 1, 11, 0, 0, 0, loc)) {
+		t.Errorf("hourly at boundary = %v", got)
+	}
+}
+
+func TestNewFileWriterRejectsUnknownInterval(t *testing.T) {
+	if _, err := newFileWriter(FileSinkConfig{Path: filepath.Join(t.TempDir(), "app.log"), RotateInterval: "weekly"}); err == nil {
+		t.Fatal("expected error for RotateInterval weekly")
+	}
+}
@@ -0,0 +1,200 @@
+package logger
+
+import (
+	"fmt"
+	"hash/fnv"
+	"math"
+	"os"
+	"sync/atomic"
+	"time"
+)
+
+// SamplingConfig untuk konfigurasi sampling log entry. Sampling dilakukan sebelum entry masuk
+// ke channel, sehingga entry yang di-sample tidak memenuhi buffer.
+type SamplingConfig struct {
+	// Sampling per key (level + template message), seperti sampler zap:
+	// First entry pertama per Interval selalu ditulis, setelah itu hanya setiap entry ke-Thereafter.
+	Interval   time.Duration // Default: 1s
+	First      int           // 0 = sampling per key nonaktif
+	Thereafter int           // 0 = drop semua entry setelah First sampai interval berikutnya
+
+	// TransactionRate adalah fraksi transaksi yang ditulis (head sampling), misalnya 0.1 = 10%.
+	// Keputusan diambil dari hash transaction ID, sehingga semua entry dari transaksi yang
+	// di-sample tetap lengkap (START, log di handler dan STOP). 0 atau >= 1 = semua transaksi.
+	TransactionRate float64
+
+	// KeepLevel dan level yang lebih penting tidak pernah di-sample (default: LevelError)
+	KeepLevel LogLevel
+
+	// ReportInterval adalah interval summary jumlah entry yang di-sample (default: 10s, negatif = nonaktif)
+	ReportInterval time.Duration
+}
+
+// samplerCounters is the number of counters; key di-hash ke salah satu counter sehingga
+// memory tetap terbatas walaupun message-nya dinamis
+const samplerCounters = 4096
+
+// sampler holds the sampling state of a logger
+type sampler struct {
+	interval   int64
+	first      uint64
+	thereafter uint64
+	rate       float64
+	keepLevel  LogLevel
+	counters   [samplerCounters]sampleCounter
+	suppressed atomic.Uint64
+}
+
+// sampleCounter counts entries of one key within the current interval
+type sampleCounter struct {
+	resetAt atomic.Int64
+	count   atomic.Uint64
+}
+
+// newSampler creates a sampler from config, atau nil jika config nil
+func newSampler(config *SamplingConfig) *sampler {
+	if config == nil {
+		return nil
+	}
+	interval := config.Interval
+	if interval <= 0 {
+		interval = time.Second
+	}
+	s := &sampler{
+		interval:  int64(interval),
+		rate:      config.TransactionRate,
+		keepLevel: resolveMinLevel(config.KeepLevel, LevelError),
+	}
+	if config.First > 0 {
+		s.first = uint64(config.First)
+	}
+	if config.Thereafter > 0 {
+		s.thereafter = uint64(config.Thereafter)
+	}
+	return s
+}
+
+// inc increments the counter, starting a new interval if the current one has ended
+func (c *sampleCounter) inc(now int64, interval int64) uint64 {
+	resetAt := c.resetAt.Load()
+	if now < resetAt {
+		return c.count.Add(1)
+	}
+	if c.resetAt.CompareAndSwap(resetAt, now+interval) {
+		c.count.Store(1)
+		return 1
+	}
+	return c.count.Add(1)
+}
+
+// sample reports whether msg must be written
+func (l *Logger) sample(msg *logMessage) bool {
+	s := l.sampler
+	if s == nil || msg.flushed != nil {
+		return true
+	}
+	if levelFromString(msg.level) <= s.keepLevel {
+		return true
+	}
+
+	// Head sampling per transaksi
+	if s.rate > 0 && s.rate < 1 {
+		transactionID := msg.uuid
+		if msg.entry != nil {
+			transactionID = msg.entry.TransactionID
+		}
+		if !sampledTransaction(transactionID, s.rate) {
+			s.suppressed.Add(1)
+			return false
+		}
+	}
+
+	// First N per interval, lalu setiap entry ke-M
+	if s.first > 0 {
+		counter := &s.counters[sampleKey(msg)%samplerCounters]
+		n := counter.inc(l.now().UnixNano(), s.interval)
+		if n > s.first && (s.thereafter == 0 || (n-s.first)%s.thereafter != 0) {
+			s.suppressed.Add(1)
+			return false
+		}
+	}
+	return true
+}
+
+// sampleKey hashes the level and message template of msg
+func sampleKey(msg *logMessage) uint32 {
+	h := fnv.New32a()
+	h.Write([]byte(msg.level))
+	switch {
+	case msg.entry != nil:
+		h.Write([]byte(msg.entry.Flag))
+		h.Write([]byte(msg.entry.Message))
+	case msg.message == "%s" && len(msg.args) == 1:
+		// Message dari slog handler dan reportDropped
+		h.Write([]byte(fmt.Sprint(msg.args[0])))
+	default:
+		h.Write([]byte(msg.message))
+	}
+	return h.Sum32()
+}
+
+// sampledTransaction reports whether the transaction belongs to the sampled fraction
+func sampledTransaction(transactionID string, rate float64) bool {
+	h := fnv.New64a()
+	h.Write([]byte(transactionID))
+	return float64(mix64(h.Sum64())) < rate*math.MaxUint64
+}
+
+// mix64 is the murmur3 finalizer. Tanpa ini, ID yang hanya berbeda di karakter terakhir
+// (misalnya ID berurutan) menghasilkan high bits FNV yang hampir sama dan ikut di-sample bersama.
+func mix64(x uint64) uint64 {
+	x ^= x >> 33
+	x *= 0xff51afd7ed558ccd
+	x ^= x >> 33
+	x *= 0xc4ceb9fe1a85ec53
+	x ^= x >> 33
+	return x
+}
+
+// Sampled returns the total number of log entries suppressed by sampling
+func (l *Logger) Sampled() uint64 {
+	if l.sampler == nil {
+		return 0
+	}
+	return l.sampler.suppressed.Load()
+}
+
+// reportSampled periodically logs a summary line when entries have been suppressed by sampling
+func (l *Logger) reportSampled(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	var reported uint64
+	for {
+		select {
+		case <-ticker.C:
+			total := l.sampler.suppressed.Load()
+			if total == reported {
+				continue
+			}
+			summary := fmt.Sprintf("Sampling: suppressed %d log entries in the last %s (total: %d)",
+				total-reported, interval, total)
+			reported = total
+
+			msg := &logMessage{
+				level:    "INFO",
+				uuid:     l.ids.NewUUID(),
+				message:  "%s",
+				args:     []interface{}{summary},
+				file:     "logger",
+				function: "reportSampled",
+			}
+			if !l.enqueueReport(msg) {
+				// Channel penuh, tulis langsung ke stderr
+				fmt.Fprintf(os.Stderr, "[LOGGER] %s\n", summary)
+			}
+		case <-l.closed:
+			return
+		}
+	}
+}
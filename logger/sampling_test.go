@@ -0,0 +1,138 @@
+package logger_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/funxdofficial/golang-module-syslog/logger"
+	"github.com/funxdofficial/golang-module-syslog/logger/logtest"
+)
+
+// newSampledLogger returns a logger whose clock does not move unless step > 0
+func newSampledLogger(t *testing.T, sampling logger.SamplingConfig, step time.Duration) (*logger.Logger, *logtest.Observer) {
+	sampling.ReportInterval = -1
+	return logtest.NewWithConfig(t, &logger.LoggerConfig{
+		MinLevel:    logger.LevelDebug,
+		Sampling:    &sampling,
+		Clock:       logtest.NewStepClock(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), step),
+		IDGenerator: logtest.NewSequenceIDs(),
+	})
+}
+
+func TestSamplingFirstThereafter(t *testing.T) {
+	tests := []struct {
+		name       string
+		first      int
+		thereafter int
+		want       int
+	}{
+		{"first only", 3, 0, 3},
+		{"first then every 5th", 3, 5, 3 + 20},
+		{"every entry", 100, 0, 100},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			appLogger, logs := newSampledLogger(t, logger.SamplingConfig{First: tt.first, Thereafter: tt.thereafter}, 0)
+
+			for i := 0; i < 103; i++ {
+				appLogger.Info("cache miss for key %d", i)
+			}
+			appLogger.Info("other message")
+
+			logs.AssertCount(t, logger.LevelInfo, tt.want+1)
+			if got := appLogger.Sampled(); got != uint64(103-tt.want) {
+				t.Errorf("Sampled = %d, want %d", got, 103-tt.want)
+			}
+		})
+	}
+}
+
+func TestSamplingKeysByLevel(t *testing.T) {
+	appLogger, logs := newSampledLogger(t, logger.SamplingConfig{First: 1}, 0)
+
+	for i := 0; i < 3; i++ {
+		appLogger.Info("same message")
+		appLogger.Debug("same message")
+	}
+	logs.AssertCount(t, logger.LevelInfo, 1)
+	logs.AssertCount(t, logger.LevelDebug, 1)
+}
+
+func TestSamplingNewInterval(t *testing.T) {
+	// Setiap panggilan clock maju 1 detik, sehingga setiap entry berada di interval baru
+	appLogger, logs := newSampledLogger(t, logger.SamplingConfig{First: 1, Interval: time.Millisecond}, time.Second)
+
+	for i := 0; i < 5; i++ {
+		appLogger.Info("tick")
+	}
+	logs.AssertCount(t, logger.LevelInfo, 5)
+	if appLogger.Sampled() != 0 {
+		t.Errorf("Sampled = %d, want 0", appLogger.Sampled())
+	}
+}
+
+func TestSamplingNeverDropsKeepLevel(t *testing.T) {
+	tests := []struct {
+		name      string
+		keepLevel logger.LogLevel
+		level     logger.LogLevel
+		log       func(*logger.Logger)
+	}{
+		{"error kept by default", 0, logger.LevelError, func(l *logger.Logger) { l.Error("db down") }},
+		{"warning kept with KeepLevel warning", logger.LevelWarning, logger.LevelWarning, func(l *logger.Logger) { l.Warning("slow") }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			appLogger, logs := newSampledLogger(t, logger.SamplingConfig{
+				First:           1,
+				TransactionRate: 0.0001,
+				KeepLevel:       tt.keepLevel,
+			}, 0)
+
+			for i := 0; i < 10; i++ {
+				tt.log(appLogger)
+			}
+			logs.AssertCount(t, tt.level, 10)
+		})
+	}
+}
+
+func TestSamplingKeepsWholeTransactions(t *testing.T) {
+	appLogger, logs := newSampledLogger(t, logger.SamplingConfig{TransactionRate: 0.5}, 0)
+
+	const transactions = 200
+	for i := 0; i < transactions; i++ {
+		ctx := appLogger.Start(context.Background(), logger.StartConfig{ServiceName: "svc"})
+		appLogger.InfoCtx(ctx, "processing")
+		appLogger.Stop(ctx, "INFO", "done", "")
+	}
+
+	starts := logs.All().Flag(logger.FlagStart)
+	for _, start := range starts {
+		if got := logs.All().TransactionID(start.TransactionID).Messages(); len(got) != 3 {
+			t.Errorf("transaction %s = %v, want START, log and STOP", start.TransactionID, got)
+		}
+	}
+	kept := len(starts)
+	if logs.Len() != 3*kept {
+		t.Errorf("got %d entries for %d transactions", logs.Len(), kept)
+	}
+	if kept < transactions/4 || kept > transactions*3/4 {
+		t.Errorf("kept %d of %d transactions with rate 0.5", kept, transactions)
+	}
+	if got := appLogger.Sampled(); got != uint64(3*(transactions-kept)) {
+		t.Errorf("Sampled = %d, want %d", got, 3*(transactions-kept))
+	}
+}
+
+func TestSamplingDisabled(t *testing.T) {
+	appLogger, logs := logtest.New(t)
+	for i := 0; i < 10; i++ {
+		appLogger.Info("same message")
+	}
+	logs.AssertCount(t, logger.LevelInfo, 10)
+	if appLogger.Sampled() != 0 {
+		t.Errorf("Sampled = %d without sampling", appLogger.Sampled())
+	}
+}
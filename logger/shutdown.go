@@ -0,0 +1,138 @@
+package logger
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+	"time"
+)
+
+// Syncer is implemented by sinks that can commit written data to stable storage (misalnya FileSink)
+type Syncer interface {
+	Sync() error
+}
+
+// Flush blocks until every entry enqueued before the call has been written and every sink flushed,
+// atau sampai ctx selesai (mengembalikan ctx.Err()). Logger tetap bisa dipakai setelah Flush.
+func (l *Logger) Flush(ctx context.Context) error {
+	return l.barrier(ctx, &logMessage{flushed: make(chan struct{})})
+}
+
+// Sync flushes like Flush and then fsyncs every sink that implements Syncer (file sinks),
+// sehingga entry tidak hilang walaupun host mati
+func (l *Logger) Sync() error {
+	return l.barrier(context.Background(), &logMessage{flushed: make(chan struct{}), sync: true})
+}
+
+// barrier sends a marker through the channel and waits until the worker reaches it.
+// Sink hanya disentuh oleh worker, jadi flush, fsync dan reopen juga dijalankan di worker.
+// Pada ModeSync marker langsung dijalankan oleh caller.
+func (l *Logger) barrier(ctx context.Context, marker *logMessage) error {
+	if l.sync {
+		l.writeSync(marker)
+		return marker.err
+	}
+	select {
+	case l.logChan <- marker:
+	case <-l.closed:
+		// Logger sudah di-close, tunggu worker selesai flush
+		l.wg.Wait()
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+	select {
+	case <-marker.flushed:
+		return marker.err
+	case <-l.closed:
+		l.wg.Wait()
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// syncSinks fsyncs every sink that implements Syncer, returning the first error
+func (l *Logger) syncSinks() error {
+	var err error
+	for _, s := range l.sinks {
+		if syncer, ok := s.sink.(Syncer); ok {
+			if syncErr := syncer.Sync(); err == nil {
+				err = syncErr
+			}
+		}
+	}
+	return err
+}
+
+// Shutdown flushes, fsyncs and closes the logger, tapi tidak menunggu lebih lama dari ctx.
+// Jika ctx selesai lebih dulu, ctx.Err() dikembalikan dan Close tetap berjalan di background.
+func (l *Logger) Shutdown(ctx context.Context) error {
+	done := make(chan error, 1)
+	go func() {
+		syncErr := l.Sync()
+		if err := l.Close(); err != nil {
+			syncErr = err
+		}
+		done <- syncErr
+	}()
+
+	select {
+	case err := <-done:
+		return err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// ShutdownOnSignal calls Shutdown with the given timeout when one of signals arrives
+// (default: SIGINT dan SIGTERM), lalu mengirim ulang signal tersebut ke proses sehingga
+// perilaku default (proses berhenti) atau signal handler aplikasi tetap berjalan.
+// Panggil stop untuk membatalkan. Jika aplikasi sudah punya graceful shutdown sendiri,
+// panggil Shutdown(ctx) di sana sebagai langkah terakhir.
+//
+//	stop := appLogger.ShutdownOnSignal(5 * time.Second)
+//	defer stop()
+func (l *Logger) ShutdownOnSignal(timeout time.Duration, signals ...os.Signal) (stop func()) {
+	if len(signals) == 0 {
+		signals = []os.Signal{os.Interrupt, syscall.SIGTERM}
+	}
+
+	ch := make(chan os.Signal, 1)
+	signal.Notify(ch, signals...)
+	done := make(chan struct{})
+
+	go func() {
+		select {
+		case sig := <-ch:
+			ctx, cancel := context.WithTimeout(context.Background(), timeout)
+			if err := l.Shutdown(ctx); err != nil {
+				fmt.Fprintf(os.Stderr, "[LOGGER ERROR] Shutdown on %v: %v\n", sig, err)
+			}
+			cancel()
+			signal.Stop(ch)
+			raise(sig)
+		case <-done:
+			signal.Stop(ch)
+		}
+	}()
+
+	var once sync.Once
+	return func() {
+		once.Do(func() { close(done) })
+	}
+}
+
+// raise re-sends sig to the current process, atau exit jika platform tidak mendukungnya
+func raise(sig os.Signal) {
+	p, err := os.FindProcess(os.Getpid())
+	if err == nil {
+		err = p.Signal(sig)
+	}
+	if err != nil {
+		os.Exit(1)
+	}
+}
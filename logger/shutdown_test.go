@@ -0,0 +1,153 @@
+package logger_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/funxdofficial/golang-module-syslog/logger"
+)
+
+// countingSink counts writes and calls to Flush, Sync and Close
+type countingSink struct {
+	writes, flushes, syncs, closes atomic.Int32
+}
+
+func (s *countingSink) Write(*logger.LogEntry) error {
+	s.writes.Add(1)
+	return nil
+}
+
+func (s *countingSink) Flush() error {
+	s.flushes.Add(1)
+	return nil
+}
+
+func (s *countingSink) Sync() error {
+	s.syncs.Add(1)
+	return nil
+}
+
+func (s *countingSink) Close() error {
+	s.closes.Add(1)
+	return nil
+}
+
+// newAsyncLogger starts an async logger writing logfmt to a buffer and to a countingSink
+func newAsyncLogger(t *testing.T) (*logger.Logger, *lockedBuffer, *countingSink) {
+	t.Helper()
+	out := &lockedBuffer{}
+	counter := &countingSink{}
+	appLogger, err := logger.StartLogger(&logger.LoggerConfig{
+		Mode:                   logger.ModeAsync,
+		OverflowPolicy:         logger.OverflowBlock,
+		OverflowReportInterval: -1,
+		Sinks: []logger.SinkConfig{
+			{Sink: logger.NewWriterSink(out, logger.FormatLogfmt)},
+			{Sink: counter},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { appLogger.Close() })
+	return appLogger, out, counter
+}
+
+func TestFlushThenRead(t *testing.T) {
+	appLogger, out, counter := newAsyncLogger(t)
+
+	for i := 0; i < 100; i++ {
+		appLogger.Info("entry %d", i)
+	}
+	if err := appLogger.Flush(context.Background()); err != nil {
+		t.Fatalf("Flush: %v", err)
+	}
+
+	// Semua entry yang di-log sebelum Flush sudah tertulis tanpa sleep
+	if got := strings.Count(out.String(), "\n"); got != 100 {
+		t.Errorf("got %d lines after Flush, want 100", got)
+	}
+	if !strings.Contains(out.String(), `message="entry 99"`) {
+		t.Error("last entry missing after Flush")
+	}
+	if counter.flushes.Load() == 0 {
+		t.Error("Flush did not flush the sinks")
+	}
+
+	// Logger tetap bisa dipakai setelah Flush
+	appLogger.Info("after flush")
+	appLogger.Flush(context.Background())
+	if !strings.Contains(out.String(), `message="after flush"`) {
+		t.Error("entry logged after Flush is missing")
+	}
+}
+
+func TestFlushAfterClose(t *testing.T) {
+	appLogger, out, _ := newAsyncLogger(t)
+	appLogger.Info("before close")
+	appLogger.Close()
+
+	if err := appLogger.Flush(context.Background()); err != nil {
+		t.Errorf("Flush after Close = %v, want nil", err)
+	}
+	if !strings.Contains(out.String(), `message="before close"`) {
+		t.Error("Close did not write the queued entry")
+	}
+}
+
+func TestShutdownFlushesSyncsAndCloses(t *testing.T) {
+	appLogger, out, counter := newAsyncLogger(t)
+	for i := 0; i < 10; i++ {
+		appLogger.Info("entry %d", i)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := appLogger.Shutdown(ctx); err != nil {
+		t.Fatalf("Shutdown: %v", err)
+	}
+
+	if got := strings.Count(out.String(), "\n"); got != 10 {
+		t.Errorf("got %d lines after Shutdown, want 10", got)
+	}
+	if counter.syncs.Load() == 0 {
+		t.Error("Shutdown did not sync the sinks")
+	}
+	if counter.closes.Load() != 1 {
+		t.Errorf("sink closed %d times, want 1", counter.closes.Load())
+	}
+}
+
+func TestFlushReturnsCtxErrWhenSinkBlocks(t *testing.T) {
+	appLogger, _, _ := newBlockedLogger(t, logger.LoggerConfig{})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if err := appLogger.Flush(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Flush = %v, want context.DeadlineExceeded", err)
+	}
+}
+
+func TestShutdownReturnsCtxErrWhenSinkBlocks(t *testing.T) {
+	appLogger, logs, sink := newBlockedLogger(t, logger.LoggerConfig{})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	if err := appLogger.Shutdown(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Shutdown = %v, want context.DeadlineExceeded", err)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("Shutdown took %s, want it to return when ctx is done", elapsed)
+	}
+
+	// Close tetap berjalan di background: setelah sink lepas semua entry tertulis
+	sink.release()
+	if got := logs.All().Messages(); strings.Join(got, ",") != "m0,m1,m2" {
+		t.Errorf("messages after background Close = %q, want m0,m1,m2", got)
+	}
+}
@@ -0,0 +1,244 @@
+package logger
+
+import (
+	"fmt"
+	"io"
+	"os"
+)
+
+// Sink is an output destination for log entries.
+// Write, Flush dan Close hanya dipanggil dari worker goroutine (atau di bawah mutex logger
+// pada ModeSync), sehingga implementasi tidak perlu mutex selama writer-nya tidak dipakai
+// bersama di tempat lain.
+// Entry yang sama dikirim ke semua sink, jadi sink tidak boleh mengubah isinya.
+type Sink interface {
+	Write(entry *LogEntry) error
+	Flush() error
+	Close() error
+}
+
+// Formatter encodes a log entry into a single line (tanpa newline)
+type Formatter interface {
+	Format(entry *LogEntry) string
+}
+
+// SinkConfig registers a sink with its own level filter
+type SinkConfig struct {
+	Sink     Sink
+	MinLevel LogLevel // Minimum level untuk sink ini (default: semua yang lolos MinLevel)
+}
+
+// sinkEntry is a registered sink with its resolved minimum level
+type sinkEntry struct {
+	sink     Sink
+	minLevel LogLevel
+}
+
+// ConsoleSink writes lines to stdout, or stderr for ERROR and above.
+// Hanya FormatText yang diberi warna ANSI; JSON, logfmt dan formatter lain ditulis plain
+// agar tetap satu object / record per baris untuk log shipper yang membaca stdout container.
+type ConsoleSink struct {
+	formatter Formatter
+}
+
+// NewConsoleSink creates a console sink. Formatter nil berarti FormatText.
+func NewConsoleSink(formatter Formatter) *ConsoleSink {
+	if formatter == nil {
+		formatter = FormatText
+	}
+	return &ConsoleSink{formatter: formatter}
+}
+
+// Write writes the entry, dengan warna ANSI sesuai level untuk FormatText
+func (s *ConsoleSink) Write(entry *LogEntry) error {
+	formatted := s.formatter.Format(entry)
+	out, color := os.Stdout, ""
+	switch entry.LogLevel {
+	case "FATAL", "PANIC":
+		out, color = os.Stderr, "\033[1;31m" // Bold red
+	case "ERROR":
+		out, color = os.Stderr, "\033[31m" // Red
+	case "WARNING":
+		color = "\033[33m" // Yellow
+	case "SUCCESS":
+		color = "\033[32m" // Green
+	case "INFO":
+		color = "\033[36m" // Cyan
+	case "DEBUG":
+		color = "\033[90m" // Gray
+	case "TRACE":
+		color = "\033[2;37m" // Dim white
+	}
+
+	var err error
+	if color != "" && s.formatter == FormatText {
+		_, err = fmt.Fprintf(out, "%s%s\033[0m\n", color, formatted)
+	} else {
+		_, err = fmt.Fprintln(out, formatted)
+	}
+	return err
+}
+
+// Flush is a no-op, stdout dan stderr tidak di-buffer
+func (s *ConsoleSink) Flush() error {
+	return nil
+}
+
+// Close is a no-op, stdout dan stderr tidak ditutup oleh logger
+func (s *ConsoleSink) Close() error {
+	return nil
+}
+
+// FileSinkConfig represents configuration for a file sink
+type FileSinkConfig struct {
+	Path      string    // Path to log file (required)
+	Formatter Formatter // Default: FormatText
+
+	// Rotasi file log (opsional)
+	MaxSizeMB      int            // Rotate jika ukuran file melebihi N MB (0 = tanpa batas)
+	RotateInterval RotateInterval // Rotate terjadwal: "hourly" atau "daily" (default: tidak terjadwal)
+	MaxBackups     int            // Jumlah file hasil rotasi yang disimpan (0 = simpan semua)
+	MaxAgeDays     int            // Hapus file hasil rotasi yang lebih tua dari N hari (0 = tidak dihapus)
+	Compress       bool           // Gzip file hasil rotasi
+
+	Clock Clock // Waktu untuk jadwal rotasi, nama backup dan MaxAgeDays (default: time.Now)
+}
+
+// FileSink writes plain lines (tanpa warna) to a log file with optional rotation
+type FileSink struct {
+	w         *fileWriter
+	formatter Formatter
+}
+
+// NewFileSink opens the log file in append mode
+func NewFileSink(config FileSinkConfig) (*FileSink, error) {
+	if config.Path == "" {
+		return nil, fmt.Errorf("Path is required for file sink")
+	}
+	w, err := newFileWriter(config)
+	if err != nil {
+		return nil, err
+	}
+	formatter := config.Formatter
+	if formatter == nil {
+		formatter = FormatText
+	}
+	return &FileSink{w: w, formatter: formatter}, nil
+}
+
+// Write writes the entry as one line, rotating the file first if needed
+func (s *FileSink) Write(entry *LogEntry) error {
+	_, err := fmt.Fprintln(s.w, s.formatter.Format(entry))
+	return err
+}
+
+// Flush is a no-op, setiap baris langsung ditulis ke file
+func (s *FileSink) Flush() error {
+	return nil
+}
+
+// Sync commits the log file to stable storage (fsync)
+func (s *FileSink) Sync() error {
+	return s.w.Sync()
+}
+
+// Reopen reopens the log file after it was renamed by an external tool (logrotate)
+func (s *FileSink) Reopen() error {
+	return s.w.Reopen()
+}
+
+// Close closes the log file and waits for pending compression/cleanup
+func (s *FileSink) Close() error {
+	return s.w.Close()
+}
+
+// WriterSink writes lines to any io.Writer (buffer, pipe, network connection, dll)
+type WriterSink struct {
+	w         io.Writer
+	formatter Formatter
+}
+
+// NewWriterSink creates a sink for w. Formatter nil berarti FormatText.
+// Jika w punya method Flush() error (misalnya *bufio.Writer) method itu dipanggil saat flush,
+// jika w punya method Sync() error method itu dipanggil oleh Logger.Sync,
+// dan jika w adalah io.Closer maka w ditutup saat logger di-close.
+func NewWriterSink(w io.Writer, formatter Formatter) *WriterSink {
+	if formatter == nil {
+		formatter = FormatText
+	}
+	return &WriterSink{w: w, formatter: formatter}
+}
+
+// Write writes the entry as one line
+func (s *WriterSink) Write(entry *LogEntry) error {
+	_, err := fmt.Fprintln(s.w, s.formatter.Format(entry))
+	return err
+}
+
+// Flush flushes the underlying writer if it is buffered
+func (s *WriterSink) Flush() error {
+	if f, ok := s.w.(interface{ Flush() error }); ok {
+		return f.Flush()
+	}
+	return nil
+}
+
+// Sync fsyncs the underlying writer if it has a Sync() error method.
+// *os.File yang bukan file biasa (stdout, pipe, terminal) dilewati.
+func (s *WriterSink) Sync() error {
+	if f, ok := s.w.(*os.File); ok {
+		if info, err := f.Stat(); err != nil || !info.Mode().IsRegular() {
+			return nil
+		}
+	}
+	if syncer, ok := s.w.(Syncer); ok {
+		return syncer.Sync()
+	}
+	return nil
+}
+
+// Close flushes and closes the underlying writer if it is an io.Closer
+func (s *WriterSink) Close() error {
+	err := s.Flush()
+	if c, ok := s.w.(io.Closer); ok {
+		if closeErr := c.Close(); err == nil {
+			err = closeErr
+		}
+	}
+	return err
+}
+
+// writeSinks sends an entry to every sink whose level filter accepts it
+func (l *Logger) writeSinks(level LogLevel, entry *LogEntry) {
+	for _, s := range l.sinks {
+		if level > s.minLevel {
+			continue
+		}
+		if err := s.sink.Write(entry); err != nil {
+			fmt.Fprintf(os.Stderr, "[LOGGER ERROR] Failed to write to %T: %v\n", s.sink, err)
+		}
+	}
+}
+
+// flushSinks flushes every sink
+func (l *Logger) flushSinks() {
+	for _, s := range l.sinks {
+		if err := s.sink.Flush(); err != nil {
+			fmt.Fprintf(os.Stderr, "[LOGGER ERROR] Failed to flush %T: %v\n", s.sink, err)
+		}
+	}
+}
+
+// closeSinks flushes and closes every sink, returning the first error
+func (l *Logger) closeSinks() error {
+	var err error
+	for _, s := range l.sinks {
+		if flushErr := s.sink.Flush(); err == nil {
+			err = flushErr
+		}
+		if closeErr := s.sink.Close(); err == nil {
+			err = closeErr
+		}
+	}
+	return err
+}
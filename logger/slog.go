@@ -0,0 +1,187 @@
+package logger
+
+import (
+	"context"
+	"log/slog"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"time"
+)
+
+// SlogLevelSuccess is the custom slog level written as SUCCESS (antara INFO dan WARN)
+const SlogLevelSuccess = slog.Level(2)
+
+// SlogHandlerOptions represents options for NewSlogHandler
+type SlogHandlerOptions struct {
+	// Level adalah minimum level slog tambahan. Default: hanya mengikuti MinLevel / SetLevel dari Logger.
+	Level slog.Leveler
+}
+
+// SlogHandler is a slog.Handler backed by the async Logger.
+// Record dikirim lewat worker yang sama, sehingga warna, format, sink dan field
+// dari context (UUID, trace, service, endpoint) sama dengan log biasa.
+type SlogHandler struct {
+	logger *Logger
+	opts   SlogHandlerOptions
+	fields []Field // Attrs dari WithAttrs, key sudah diberi prefix group
+	group  string  // Prefix group aktif, misalnya "request.header."
+}
+
+// NewSlogHandler creates a slog.Handler that writes through l
+func NewSlogHandler(l *Logger, opts *SlogHandlerOptions) *SlogHandler {
+	h := &SlogHandler{logger: l}
+	if opts != nil {
+		h.opts = *opts
+	}
+	return h
+}
+
+// levelFromSlog maps a slog level to a level string of this package
+func levelFromSlog(level slog.Level) string {
+	switch {
+	case level >= slog.LevelError:
+		return "ERROR"
+	case level >= slog.LevelWarn:
+		return "WARNING"
+	case level >= SlogLevelSuccess:
+		return "SUCCESS"
+	case level >= slog.LevelInfo:
+		return "INFO"
+	case level >= slog.LevelDebug:
+		return "DEBUG"
+	}
+	return "TRACE"
+}
+
+// Enabled reports whether the logger writes records of the given level
+func (h *SlogHandler) Enabled(_ context.Context, level slog.Level) bool {
+	if h.opts.Level != nil && level < h.opts.Level.Level() {
+		return false
+	}
+	return h.logger.enabled(levelFromString(levelFromSlog(level)))
+}
+
+// Handle enqueues the record to the async worker.
+// Jika context berasal dari Start / middleware (ada start time, service, transaction atau trace ID),
+// record ditulis dengan mandatory fields seperti LogWithBody; selain itu seperti InfoCtx.
+func (h *SlogHandler) Handle(ctx context.Context, r slog.Record) error {
+	level := levelFromSlog(r.Level)
+
+	fields := append([]Field(nil), h.logger.entryFields(ctx)...)
+	fields = append(fields, h.fields...)
+	r.Attrs(func(a slog.Attr) bool {
+		fields = appendSlogAttr(fields, h.group, a)
+		return true
+	})
+
+	file, line, function := "unknown", 0, "unknown"
+	if r.PC != 0 {
+		frame, _ := runtime.CallersFrames([]uintptr{r.PC}).Next()
+		parts := strings.Split(frame.Function, ".")
+		file, line, function = filepath.Base(frame.File), frame.Line, parts[len(parts)-1]
+	}
+
+	var msg *logMessage
+	if isTransactionContext(ctx) {
+		entry := h.logger.newMandatoryEntry(ctx, level, "", r.Message, "")
+		entry.Fields = fields
+		entry.File, entry.Line, entry.Function = file, line, function
+		if !r.Time.IsZero() {
+			entry.Time = r.Time
+			entry.Timestamp = r.Time.Format(timestampLayout)
+		}
+		msg = &logMessage{level: level, entry: entry}
+	} else {
+		trace := h.logger.traceContext(ctx)
+		msg = &logMessage{
+			level:    level,
+			uuid:     h.logger.uuidFromContext(ctx),
+			message:  "%s",
+			args:     []interface{}{r.Message},
+			fields:   fields,
+			file:     file,
+			line:     line,
+			function: function,
+			time:     r.Time,
+
+			traceID:    trace.TraceID,
+			spanID:     trace.SpanID,
+			traceFlags: trace.TraceFlags,
+		}
+	}
+
+	h.logger.enqueue(msg)
+	return nil
+}
+
+// WithAttrs returns a handler that adds attrs to every record
+func (h *SlogHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	if len(attrs) == 0 {
+		return h
+	}
+	h2 := *h
+	h2.fields = append([]Field(nil), h.fields...)
+	for _, a := range attrs {
+		h2.fields = appendSlogAttr(h2.fields, h.group, a)
+	}
+	return &h2
+}
+
+// WithGroup returns a handler that prefixes the keys of subsequent attrs with name
+func (h *SlogHandler) WithGroup(name string) slog.Handler {
+	if name == "" {
+		return h
+	}
+	h2 := *h
+	h2.group = h.group + name + "."
+	return &h2
+}
+
+// isTransactionContext reports whether ctx was created by Start, StartFromRequest or a middleware
+func isTransactionContext(ctx context.Context) bool {
+	if _, ok := getStartTimeFromContext(ctx); ok {
+		return true
+	}
+	return getValueFromContext(ctx, ServiceNameKey, "") != "" ||
+		getValueFromContext(ctx, TransactionIDKey, "") != "" ||
+		getValueFromContext(ctx, TraceIDKey, "") != ""
+}
+
+// appendSlogAttr converts a slog attribute to fields, flattening groups as "group.key"
+func appendSlogAttr(fields []Field, prefix string, a slog.Attr) []Field {
+	a.Value = a.Value.Resolve()
+	if a.Equal(slog.Attr{}) {
+		return fields
+	}
+
+	if a.Value.Kind() == slog.KindGroup {
+		attrs := a.Value.Group()
+		if len(attrs) == 0 {
+			return fields
+		}
+		// Group tanpa key di-inline ke level saat ini
+		if a.Key != "" {
+			prefix += a.Key + "."
+		}
+		for _, ga := range attrs {
+			fields = appendSlogAttr(fields, prefix, ga)
+		}
+		return fields
+	}
+
+	key := prefix + a.Key
+	switch a.Value.Kind() {
+	case slog.KindString:
+		return append(fields, String(key, a.Value.String()))
+	case slog.KindInt64:
+		return append(fields, Int64(key, a.Value.Int64()))
+	case slog.KindBool:
+		return append(fields, Bool(key, a.Value.Bool()))
+	case slog.KindDuration:
+		return append(fields, Duration(key, a.Value.Duration()))
+	case slog.KindTime:
+		return append(fields, String(key, a.Value.Time().Format(time.RFC3339Nano)))
+	}
+	return append(fields, Any(key, a.Value.Any()))
+}
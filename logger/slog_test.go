@@ -0,0 +1,195 @@
+package logger_test
+
+import (
+	"context"
+	"log/slog"
+	"strings"
+	"testing"
+	"testing/slogtest"
+	"time"
+
+	"github.com/funxdofficial/golang-module-syslog/logger"
+	"github.com/funxdofficial/golang-module-syslog/logger/logtest"
+)
+
+// zeroClock returns the zero time, sehingga entry tanpa Record.Time tidak punya waktu
+type zeroClock struct{}
+
+func (zeroClock) Now() time.Time { return time.Time{} }
+
+// slogResult converts a recorded entry to the map shape expected by testing/slogtest.
+// Field "a.b" dari group yang di-flatten dikembalikan menjadi map bertingkat.
+func slogResult(e logtest.Entry) map[string]any {
+	m := map[string]any{
+		slog.LevelKey:   e.LogLevel,
+		slog.MessageKey: e.Message,
+	}
+	if !e.Time.IsZero() {
+		m[slog.TimeKey] = e.Time
+	}
+	for _, f := range e.Fields {
+		parts := strings.Split(f.Key, ".")
+		group := m
+		for _, p := range parts[:len(parts)-1] {
+			sub, ok := group[p].(map[string]any)
+			if !ok {
+				sub = map[string]any{}
+				group[p] = sub
+			}
+			group = sub
+		}
+		group[parts[len(parts)-1]] = f.Value
+	}
+	return m
+}
+
+func TestSlogHandlerConformance(t *testing.T) {
+	appLogger, logs := logtest.NewWithConfig(t, &logger.LoggerConfig{
+		MinLevel: logger.LevelTrace,
+		Clock:    zeroClock{},
+	})
+	handler := logger.NewSlogHandler(appLogger, nil)
+
+	results := func() []map[string]any {
+		var ms []map[string]any
+		for _, e := range logs.All() {
+			ms = append(ms, slogResult(e))
+		}
+		return ms
+	}
+	if err := slogtest.TestHandler(handler, results); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestSlogHandlerLevels(t *testing.T) {
+	tests := []struct {
+		level slog.Level
+		want  logger.LogLevel
+	}{
+		{slog.LevelDebug - 4, logger.LevelTrace},
+		{slog.LevelDebug, logger.LevelDebug},
+		{slog.LevelDebug + 1, logger.LevelDebug},
+		{slog.LevelInfo, logger.LevelInfo},
+		{logger.SlogLevelSuccess, logger.LevelSuccess},
+		{slog.LevelWarn, logger.LevelWarning},
+		{slog.LevelError, logger.LevelError},
+		{slog.LevelError + 4, logger.LevelError},
+	}
+	for _, tt := range tests {
+		t.Run(tt.level.String(), func(t *testing.T) {
+			appLogger, logs := logtest.New(t)
+			slog.New(logger.NewSlogHandler(appLogger, nil)).Log(context.Background(), tt.level, "message")
+
+			all := logs.All()
+			if len(all) != 1 {
+				t.Fatalf("got %d entries, want 1", len(all))
+			}
+			if got := all[0].Level(); got != tt.want {
+				t.Errorf("level = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSlogHandlerEnabled(t *testing.T) {
+	appLogger, logs := logtest.NewWithConfig(t, &logger.LoggerConfig{MinLevel: logger.LevelInfo})
+	handler := logger.NewSlogHandler(appLogger, &logger.SlogHandlerOptions{Level: slog.LevelWarn})
+	ctx := context.Background()
+
+	tests := []struct {
+		level slog.Level
+		want  bool
+	}{
+		{slog.LevelDebug, false}, // Di bawah MinLevel Logger
+		{slog.LevelInfo, false},  // Di bawah opts.Level
+		{slog.LevelWarn, true},
+		{slog.LevelError, true},
+	}
+	for _, tt := range tests {
+		if got := handler.Enabled(ctx, tt.level); got != tt.want {
+			t.Errorf("Enabled(%s) = %v, want %v", tt.level, got, tt.want)
+		}
+	}
+
+	// SetLevel di Logger langsung berlaku untuk handler
+	appLogger.SetLevel(logger.LevelError)
+	if handler.Enabled(ctx, slog.LevelWarn) {
+		t.Error("Enabled(WARN) = true after SetLevel(ERROR)")
+	}
+
+	slog.New(handler).Warn("filtered")
+	if logs.Len() != 0 {
+		t.Errorf("got %d entries, want 0", logs.Len())
+	}
+}
+
+func TestSlogHandlerAttrsAndGroups(t *testing.T) {
+	appLogger, logs := logtest.New(t)
+	log := slog.New(logger.NewSlogHandler(appLogger, nil)).
+		With("service", "orders").
+		WithGroup("request").
+		With(slog.String("method", "POST"))
+
+	log.Info("handled",
+		slog.Int("status", 201),
+		slog.Bool("cached", false),
+		slog.Duration("took", 1500*time.Millisecond),
+		slog.Group("header", slog.String("accept", "json")),
+		slog.Group("", slog.String("inline", "yes")),
+		slog.Group("empty"),
+		slog.Time("at", time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)),
+		slog.Any("tags", []string{"a"}),
+	)
+
+	entry := logs.AssertLogged(t, logger.LevelInfo, "handled")
+	wants := []logger.Field{
+		logger.String("service", "orders"),
+		logger.String("request.method", "POST"),
+		logger.Int64("request.status", 201),
+		logger.Bool("request.cached", false),
+		logger.Duration("request.took", 1500*time.Millisecond),
+		logger.String("request.header.accept", "json"),
+		logger.String("request.inline", "yes"),
+		logger.String("request.at", "2024-01-02T03:04:05Z"),
+	}
+	for _, want := range wants {
+		got, ok := entry.Field(want.Key)
+		if !ok {
+			t.Errorf("field %s missing; fields = %v", want.Key, entry.Fields)
+			continue
+		}
+		if got != want.Value {
+			t.Errorf("field %s = %#v, want %#v", want.Key, got, want.Value)
+		}
+	}
+	if _, ok := entry.Field("request.empty"); ok {
+		t.Error("empty group must not produce a field")
+	}
+	if got, ok := entry.Field("request.tags"); !ok || strings.Join(got.([]string), ",") != "a" {
+		t.Errorf("field request.tags = %#v", got)
+	}
+	if entry.File != "slog_test.go" || entry.Function == "unknown" {
+		t.Errorf("caller = %s (%s), want the slog call site", entry.Caller(), entry.Function)
+	}
+}
+
+func TestSlogHandlerTransactionContext(t *testing.T) {
+	appLogger, logs := logtest.New(t)
+	ctx := appLogger.Start(context.Background(), logger.StartConfig{
+		ServiceName:   "orders",
+		Endpoint:      "/orders",
+		Method:        "POST",
+		TransactionID: "txn-1",
+	})
+
+	slog.New(logger.NewSlogHandler(appLogger, nil)).InfoContext(ctx, "inside transaction", "order_id", 7)
+
+	entry := logs.AssertLogged(t, logger.LevelInfo, "inside transaction")
+	if entry.TransactionID != "txn-1" || entry.ServiceName != "orders" || entry.Endpoint != "/orders" {
+		t.Errorf("mandatory fields not filled: %+v", entry.LogEntry)
+	}
+	if got, _ := entry.Field("order_id"); got != int64(7) {
+		t.Errorf("field order_id = %#v, want 7", got)
+	}
+}
@@ -0,0 +1,113 @@
+package logger
+
+import (
+	"context"
+	"sync/atomic"
+	"time"
+)
+
+// SpanDepthKey is the key for storing the nesting depth of the current span in context
+// (0 = level request, 1 = Span pertama di dalam request, dst)
+const SpanDepthKey ContextKey = "logger_span_depth"
+
+// spanKey is the key of the span started by Logger.Span
+type spanKey struct{}
+
+// spanInfo is a span started by Logger.Span
+type spanInfo struct {
+	id         string
+	otelSpanID string // Span dari LoggerConfig.SpanContext saat Span dimulai
+}
+
+// Span starts a timed child operation of the current request (misalnya query DB atau API eksternal)
+// dan menulis entry START. Panggil done dengan error hasil operasi untuk menulis entry STOP
+// dengan durasi (presisi time.Duration) dan status; err != nil ditulis sebagai ERROR.
+// Context yang dikembalikan membawa span ID baru, sehingga log, Span dan Transport di dalamnya
+// menjadi child dari span ini.
+//
+//	ctx, done := appLogger.Span(ctx, "db.query")
+//	rows, err := db.QueryContext(ctx, query)
+//	done(err)
+func (l *Logger) Span(ctx context.Context, name string) (context.Context, func(err error)) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	if getValueFromContext(ctx, UUIDKey, "") == "" {
+		// Di luar request: START dan STOP tetap memakai transaction ID yang sama
+		ctx = WithUUID(ctx, l.ids.NewUUID())
+	}
+
+	parent := l.traceContext(ctx)
+	depth := getSpanDepthFromContext(ctx) + 1
+	span := &spanInfo{id: l.ids.NewSpanID()}
+	if l.spanCtx != nil {
+		if otelSpan, ok := l.spanCtx(ctx); ok {
+			span.otelSpanID = otelSpan.SpanID
+		}
+	}
+
+	ctx = context.WithValue(ctx, SpanIDKey, span.id)
+	ctx = context.WithValue(ctx, ParentSpanIDKey, parent.SpanID)
+	ctx = context.WithValue(ctx, SpanDepthKey, depth)
+	ctx = context.WithValue(ctx, spanKey{}, span)
+	if parent.TraceID != "" {
+		ctx = WithTraceID(ctx, parent.TraceID)
+		ctx = context.WithValue(ctx, TraceFlagsKey, parent.TraceFlags)
+	}
+
+	fields := []Field{
+		String("span", name),
+		String("parent_span_id", parent.SpanID),
+		Int("depth", depth),
+	}
+	l.logSpan(ctx, "INFO", FlagStart, name, 0, fields)
+
+	start := l.now()
+	var ended atomic.Bool
+	return ctx, func(err error) {
+		// STOP hanya ditulis sekali
+		if !ended.CompareAndSwap(false, true) {
+			return
+		}
+		duration := l.now().Sub(start)
+		level, stop := "INFO", append(fields[:len(fields):len(fields)], String("status", "ok"))
+		if err != nil {
+			level, stop = "ERROR", append(fields[:len(fields):len(fields)], String("status", "error"), Err(err))
+		}
+		l.logSpan(ctx, level, FlagStop, name, duration, stop)
+	}
+}
+
+// logSpan writes a START or STOP entry of a span; ExecutionTime adalah durasi span, bukan request
+func (l *Logger) logSpan(ctx context.Context, level string, flag LogFlag, name string, duration time.Duration, fields []Field) {
+	if !l.enabled(levelFromString(level)) {
+		return
+	}
+	entry := l.newMandatoryEntry(ctx, level, flag, name, "")
+	entry.ExecutionTime = ""
+	if flag == FlagStop {
+		entry.ExecutionTime = duration.String()
+	}
+	entry.Fields = append(entry.Fields[:len(entry.Fields):len(entry.Fields)], fields...)
+	entry.File, entry.Line, entry.Function = getExternalCallerInfo()
+
+	l.enqueue(&logMessage{level: level, entry: entry})
+}
+
+// getSpanDepthFromContext returns the nesting depth of the current span (0 jika bukan di dalam Span)
+func getSpanDepthFromContext(ctx context.Context) int {
+	if depth, ok := ctx.Value(SpanDepthKey).(int); ok {
+		return depth
+	}
+	return 0
+}
+
+// loggerSpan returns the span started by Logger.Span in ctx, jika span tersebut masih span
+// yang paling dalam (tidak ada span OpenTelemetry baru yang dimulai di dalamnya)
+func loggerSpan(ctx context.Context, otelSpanID string) (*spanInfo, bool) {
+	span, ok := ctx.Value(spanKey{}).(*spanInfo)
+	if !ok || span.otelSpanID != otelSpanID {
+		return nil, false
+	}
+	return span, true
+}
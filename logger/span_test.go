@@ -0,0 +1,181 @@
+package logger_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/funxdofficial/golang-module-syslog/logger"
+	"github.com/funxdofficial/golang-module-syslog/logger/logtest"
+)
+
+// newSpanLogger returns a logger with a clock that advances 1ms per call and sequential IDs
+func newSpanLogger(t *testing.T, minLevel logger.LogLevel) (*logger.Logger, *logtest.Observer) {
+	return logtest.NewWithConfig(t, &logger.LoggerConfig{
+		MinLevel:    minLevel,
+		Clock:       logtest.NewStepClock(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), time.Millisecond),
+		IDGenerator: logtest.NewSequenceIDs(),
+	})
+}
+
+// spanEntry returns the single entry of the span with the given flag
+func spanEntry(t *testing.T, logs *logtest.Observer, name string, flag logger.LogFlag) logtest.Entry {
+	t.Helper()
+	entries := logs.All().Field("span", name).Flag(flag)
+	if len(entries) != 1 {
+		t.Fatalf("got %d %s entries for span %s, want 1", len(entries), flag, name)
+	}
+	return entries[0]
+}
+
+func TestSpanStartStop(t *testing.T) {
+	appLogger, logs := newSpanLogger(t, logger.LevelTrace)
+	ctx := appLogger.Start(context.Background(), logger.StartConfig{TransactionID: "txn-1"})
+
+	_, done := appLogger.Span(ctx, "db.query")
+	done(nil)
+
+	start := spanEntry(t, logs, "db.query", logger.FlagStart)
+	stop := spanEntry(t, logs, "db.query", logger.FlagStop)
+	for _, e := range []logtest.Entry{start, stop} {
+		if e.TransactionID != "txn-1" || e.Message != "db.query" || e.Level() != logger.LevelInfo {
+			t.Errorf("%s entry = %s txn=%s %q", e.Flag, e.Level(), e.TransactionID, e.Message)
+		}
+		if e.SpanID != start.SpanID || e.SpanID == "" {
+			t.Errorf("%s span ID = %q, want the same span ID on START and STOP", e.Flag, e.SpanID)
+		}
+	}
+	if start.ExecutionTime != "" {
+		t.Errorf("START ExecutionTime = %q, want empty", start.ExecutionTime)
+	}
+	if status, _ := stop.Field("status"); status != "ok" {
+		t.Errorf("STOP status = %v, want ok", status)
+	}
+
+	// Dengan StepClock durasinya tepat: clock maju 1ms per panggilan antara start dan STOP
+	if stop.ExecutionTime != "1ms" {
+		t.Errorf("STOP ExecutionTime = %q, want 1ms", stop.ExecutionTime)
+	}
+}
+
+func TestSpanDurationFollowsClock(t *testing.T) {
+	appLogger, logs := newSpanLogger(t, logger.LevelTrace)
+	ctx := appLogger.Start(context.Background(), logger.StartConfig{TransactionID: "txn-1"})
+
+	_, done := appLogger.Span(ctx, "api.call")
+	// Setiap entry di dalam span mengambil satu timestamp dari clock
+	appLogger.InfoCtx(ctx, "retrying")
+	appLogger.InfoCtx(ctx, "retrying")
+	done(nil)
+
+	if got := spanEntry(t, logs, "api.call", logger.FlagStop).ExecutionTime; got != "3ms" {
+		t.Errorf("STOP ExecutionTime = %q, want 3ms", got)
+	}
+}
+
+func TestSpanParentChild(t *testing.T) {
+	appLogger, logs := newSpanLogger(t, logger.LevelTrace)
+	ctx := appLogger.Start(context.Background(), logger.StartConfig{TransactionID: "txn-1"})
+	request := logs.All().Flag(logger.FlagStart)[0]
+
+	outerCtx, outerDone := appLogger.Span(ctx, "outer")
+	innerCtx, innerDone := appLogger.Span(outerCtx, "inner")
+	appLogger.InfoCtx(innerCtx, "inside inner")
+	innerDone(nil)
+	outerDone(nil)
+
+	outer := spanEntry(t, logs, "outer", logger.FlagStart)
+	inner := spanEntry(t, logs, "inner", logger.FlagStart)
+
+	tests := []struct {
+		entry      logtest.Entry
+		parentSpan string
+		depth      int64
+	}{
+		{outer, request.SpanID, 1},
+		{inner, outer.SpanID, 2},
+	}
+	for _, tt := range tests {
+		if parent, _ := tt.entry.Field("parent_span_id"); parent != tt.parentSpan {
+			t.Errorf("span %s parent_span_id = %v, want %s", tt.entry.Message, parent, tt.parentSpan)
+		}
+		if depth, _ := tt.entry.Field("depth"); depth != tt.depth {
+			t.Errorf("span %s depth = %v, want %d", tt.entry.Message, depth, tt.depth)
+		}
+		if tt.entry.TraceID != request.TraceID {
+			t.Errorf("span %s trace ID = %s, want the request trace ID %s", tt.entry.Message, tt.entry.TraceID, request.TraceID)
+		}
+	}
+	if outer.SpanID == request.SpanID || inner.SpanID == outer.SpanID {
+		t.Errorf("spans must get new IDs: request=%s outer=%s inner=%s", request.SpanID, outer.SpanID, inner.SpanID)
+	}
+
+	// Log di dalam span memakai span ID dari span tersebut
+	if got := logs.AssertLogged(t, logger.LevelInfo, "inside inner").SpanID; got != inner.SpanID {
+		t.Errorf("entry inside inner span has span ID %s, want %s", got, inner.SpanID)
+	}
+	// STOP ditulis dari dalam ke luar
+	var stops []string
+	for _, e := range logs.All().Flag(logger.FlagStop) {
+		stops = append(stops, e.Message)
+	}
+	if len(stops) != 2 || stops[0] != "inner" || stops[1] != "outer" {
+		t.Errorf("STOP order = %v, want [inner outer]", stops)
+	}
+}
+
+func TestSpanError(t *testing.T) {
+	appLogger, logs := newSpanLogger(t, logger.LevelTrace)
+
+	_, done := appLogger.Span(context.Background(), "db.query")
+	done(errors.New("connection refused"))
+	// STOP hanya ditulis sekali
+	done(nil)
+
+	stops := logs.All().Flag(logger.FlagStop)
+	if len(stops) != 1 {
+		t.Fatalf("got %d STOP entries, want 1", len(stops))
+	}
+	stop := stops[0]
+	if stop.Level() != logger.LevelError {
+		t.Errorf("STOP level = %s, want ERROR", stop.Level())
+	}
+	if status, _ := stop.Field("status"); status != "error" {
+		t.Errorf("STOP status = %v, want error", status)
+	}
+	if err, _ := stop.Field("error"); err == nil || err.(error).Error() != "connection refused" {
+		t.Errorf("STOP error = %v, want connection refused", err)
+	}
+}
+
+func TestSpanOutsideRequest(t *testing.T) {
+	appLogger, logs := newSpanLogger(t, logger.LevelTrace)
+
+	_, done := appLogger.Span(context.Background(), "cron.job")
+	done(nil)
+
+	start := spanEntry(t, logs, "cron.job", logger.FlagStart)
+	stop := spanEntry(t, logs, "cron.job", logger.FlagStop)
+	if start.UUID == "" || start.UUID != stop.UUID {
+		t.Errorf("START UUID = %q, STOP UUID = %q, want the same generated ID", start.UUID, stop.UUID)
+	}
+	if depth, _ := start.Field("depth"); depth != int64(1) {
+		t.Errorf("depth = %v, want 1", depth)
+	}
+}
+
+func TestSpanRespectsMinLevel(t *testing.T) {
+	appLogger, logs := newSpanLogger(t, logger.LevelWarning)
+
+	_, ok := appLogger.Span(context.Background(), "ok")
+	ok(nil)
+	_, failed := appLogger.Span(context.Background(), "failed")
+	failed(errors.New("boom"))
+
+	// START dan STOP sukses (INFO) di-filter, STOP gagal (ERROR) tetap ditulis
+	if got := logs.Len(); got != 1 {
+		t.Fatalf("got %d entries, want 1", got)
+	}
+	spanEntry(t, logs, "failed", logger.FlagStop)
+}
@@ -0,0 +1,353 @@
+package logger
+
+import (
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// SyslogFacility represents the syslog facility code (RFC 5424 section 6.2.1)
+type SyslogFacility int
+
+const (
+	FacilityKern SyslogFacility = iota
+	FacilityUser
+	FacilityMail
+	FacilityDaemon
+	FacilityAuth
+	FacilitySyslog
+	FacilityLPR
+	FacilityNews
+	FacilityUUCP
+	FacilityCron
+	FacilityAuthPriv
+	FacilityFTP
+	FacilityNTP
+	FacilityAudit
+	FacilityAlert
+	FacilityClock
+	FacilityLocal0
+	FacilityLocal1
+	FacilityLocal2
+	FacilityLocal3
+	FacilityLocal4
+	FacilityLocal5
+	FacilityLocal6
+	FacilityLocal7
+)
+
+// SyslogSeverity represents the syslog severity code (RFC 5424 section 6.2.1)
+type SyslogSeverity int
+
+const (
+	SeverityEmergency SyslogSeverity = iota // System is unusable
+	SeverityAlert                           // Action must be taken immediately
+	SeverityCritical                        // Critical conditions
+	SeverityError                           // Error conditions
+	SeverityWarning                         // Warning conditions
+	SeverityNotice                          // Normal but significant condition
+	SeverityInfo                            // Informational messages
+	SeverityDebug                           // Debug-level messages
+)
+
+// SyslogFormat represents the syslog message format
+type SyslogFormat string
+
+const (
+	SyslogRFC5424 SyslogFormat = "rfc5424" // Format modern (default), dengan STRUCTURED-DATA
+	SyslogRFC3164 SyslogFormat = "rfc3164" // Format BSD legacy
+)
+
+// syslogSDID is the SD-ID used for the structured data element of RFC 5424 frames
+const syslogSDID = "logger@32473"
+
+// localSyslogPaths are the unix sockets tried when SyslogNetwork is empty
+var localSyslogPaths = []string{"/dev/log", "/var/run/syslog", "/var/run/log"}
+
+// SyslogConfig represents configuration for a syslog sink
+type SyslogConfig struct {
+	Network  string         // "udp", "tcp", "unix", "unixgram" atau "" untuk local syslog (/dev/log)
+	Address  string         // Address collector (host:port) atau path unix socket
+	Facility SyslogFacility // Facility syslog (default: FacilityUser)
+	AppName  string         // APP-NAME / TAG (default: nama binary)
+	Format   SyslogFormat   // SyslogRFC5424 (default) atau SyslogRFC3164
+	Hostname string         // HOSTNAME di header (default: os.Hostname)
+}
+
+// SyslogSink delivers frames to a local or remote syslog daemon.
+// Hanya dipanggil dari worker goroutine, jadi tidak perlu mutex.
+type SyslogSink struct {
+	network  string
+	address  string
+	format   SyslogFormat
+	facility SyslogFacility
+	appName  string
+	hostname string
+	pid      int
+	conn     net.Conn
+}
+
+// NewSyslogSink creates a syslog sink and connects to the daemon. Bisa dipakai di
+// LoggerConfig.Sinks bersama console dan file.
+//
+//	syslog, err := logger.NewSyslogSink(logger.SyslogConfig{Network: "udp", Address: "rsyslog.internal:514"})
+func NewSyslogSink(config SyslogConfig) (*SyslogSink, error) {
+	switch config.Network {
+	case "":
+	case "udp", "udp4", "udp6", "tcp", "tcp4", "tcp6", "unix", "unixgram":
+		if config.Address == "" {
+			return nil, fmt.Errorf("syslog Address is required when Network is '%s'", config.Network)
+		}
+	default:
+		return nil, fmt.Errorf("unsupported syslog Network '%s'", config.Network)
+	}
+
+	format := config.Format
+	if format == "" {
+		format = SyslogRFC5424
+	}
+	if format != SyslogRFC5424 && format != SyslogRFC3164 {
+		return nil, fmt.Errorf("unsupported syslog Format '%s'", format)
+	}
+
+	// Facility kern (0) dicadangkan untuk kernel, dipakai sebagai default LOG_USER
+	facility := config.Facility
+	if facility == FacilityKern {
+		facility = FacilityUser
+	}
+	if facility < FacilityKern || facility > FacilityLocal7 {
+		return nil, fmt.Errorf("invalid syslog Facility %d", facility)
+	}
+
+	appName := config.AppName
+	if appName == "" {
+		appName = filepath.Base(os.Args[0])
+	}
+	hostname := config.Hostname
+	if hostname == "" {
+		hostname = getHostname()
+	}
+
+	w := &SyslogSink{
+		network:  config.Network,
+		address:  config.Address,
+		format:   format,
+		facility: facility,
+		appName:  appName,
+		hostname: hostname,
+		pid:      os.Getpid(),
+	}
+	if err := w.connect(); err != nil {
+		return nil, fmt.Errorf("failed to connect to syslog: %w", err)
+	}
+	return w, nil
+}
+
+// connect dials the configured syslog endpoint
+func (w *SyslogSink) connect() error {
+	if w.conn != nil {
+		w.conn.Close()
+		w.conn = nil
+	}
+
+	if w.network != "" {
+		conn, err := net.Dial(w.network, w.address)
+		if err != nil {
+			return err
+		}
+		w.conn = conn
+		return nil
+	}
+
+	// Local syslog: coba unix datagram lalu unix stream di lokasi standar
+	paths := localSyslogPaths
+	if w.address != "" {
+		paths = []string{w.address}
+	}
+	for _, network := range []string{"unixgram", "unix"} {
+		for _, path := range paths {
+			conn, err := net.Dial(network, path)
+			if err == nil {
+				w.network = network
+				w.address = path
+				w.conn = conn
+				return nil
+			}
+		}
+	}
+	return fmt.Errorf("local syslog socket not found")
+}
+
+// isStream reports whether the transport needs explicit message framing
+func (w *SyslogSink) isStream() bool {
+	switch w.network {
+	case "tcp", "tcp4", "tcp6", "unix":
+		return true
+	}
+	return false
+}
+
+// write sends one syslog message, reconnecting once if the connection is broken
+func (w *SyslogSink) write(severity SyslogSeverity, t time.Time, msgID string, data [][2]string, message string) error {
+	frame := w.frame(w.build(severity, t, msgID, data, message))
+
+	if w.conn != nil {
+		if _, err := w.conn.Write(frame); err == nil {
+			return nil
+		}
+	}
+	if err := w.connect(); err != nil {
+		return err
+	}
+	_, err := w.conn.Write(frame)
+	return err
+}
+
+// frame applies transport framing: octet counting for TCP (RFC 6587),
+// newline for unix stream sockets and none for datagrams
+func (w *SyslogSink) frame(msg string) []byte {
+	switch {
+	case strings.HasPrefix(w.network, "tcp"):
+		return []byte(strconv.Itoa(len(msg)) + " " + msg)
+	case w.isStream():
+		return []byte(msg + "\n")
+	}
+	return []byte(msg)
+}
+
+// build formats the PRI, HEADER, STRUCTURED-DATA and MSG parts
+func (w *SyslogSink) build(severity SyslogSeverity, t time.Time, msgID string, data [][2]string, message string) string {
+	pri := int(w.facility)*8 + int(severity)
+
+	if w.format == SyslogRFC3164 {
+		// <PRI>Mmm dd hh:mm:ss HOSTNAME TAG[PID]: MSG
+		tag := syslogHeaderField(w.appName, 32)
+		return fmt.Sprintf("<%d>%s %s %s[%d]: %s",
+			pri, t.Format(time.Stamp), syslogHeaderField(w.hostname, 255), tag, w.pid, message)
+	}
+
+	// <PRI>1 TIMESTAMP HOSTNAME APP-NAME PROCID MSGID STRUCTURED-DATA MSG
+	return fmt.Sprintf("<%d>1 %s %s %s %d %s %s %s",
+		pri,
+		t.Format("2006-01-02T15:04:05.000000Z07:00"),
+		syslogHeaderField(w.hostname, 255),
+		syslogHeaderField(w.appName, 48),
+		w.pid,
+		syslogHeaderField(msgID, 32),
+		syslogStructuredData(data),
+		message)
+}
+
+// Flush is a no-op, setiap message langsung dikirim
+func (w *SyslogSink) Flush() error {
+	return nil
+}
+
+// Close closes the connection to the syslog daemon
+func (w *SyslogSink) Close() error {
+	if w.conn == nil {
+		return nil
+	}
+	err := w.conn.Close()
+	w.conn = nil
+	return err
+}
+
+// syslogHeaderField returns a header field limited to printable US-ASCII without spaces,
+// or the NILVALUE "-" if empty
+func syslogHeaderField(value string, maxLen int) string {
+	if value == "" {
+		return "-"
+	}
+	var b strings.Builder
+	for _, r := range value {
+		if b.Len() >= maxLen {
+			break
+		}
+		if r < 33 || r > 126 {
+			r = '_'
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+// syslogParamName returns a valid SD-NAME: printable US-ASCII tanpa '=', spasi, ']' dan '"', maksimal 32 karakter
+func syslogParamName(name string) string {
+	name = syslogHeaderField(name, 32)
+	return strings.NewReplacer("=", "_", "]", "_", `"`, "_").Replace(name)
+}
+
+// syslogStructuredData renders the params as a single SD-ELEMENT, skipping empty values
+func syslogStructuredData(data [][2]string) string {
+	var b strings.Builder
+	for _, param := range data {
+		if param[1] == "" {
+			continue
+		}
+		if b.Len() == 0 {
+			b.WriteString("[" + syslogSDID)
+		}
+		b.WriteString(" " + param[0] + `="`)
+		b.WriteString(strings.NewReplacer(`\`, `\\`, `"`, `\"`, `]`, `\]`).Replace(param[1]))
+		b.WriteString(`"`)
+	}
+	if b.Len() == 0 {
+		return "-"
+	}
+	b.WriteString("]")
+	return b.String()
+}
+
+// Write sends a log entry to the syslog daemon
+func (w *SyslogSink) Write(entry *LogEntry) error {
+	var msgID string
+	var data [][2]string
+	if entry.TransactionID != "" {
+		msgID = string(entry.Flag)
+		data = [][2]string{
+			{"level", entry.LogLevel},
+			{"txn", entry.TransactionID},
+			{"trace", entry.TraceID},
+			{"span", entry.SpanID},
+			{"service", entry.ServiceName},
+			{"endpoint", entry.Endpoint},
+			{"method", entry.MethodType},
+			{"duration", entry.ExecutionTime},
+			{"ip", entry.ServerIP},
+			{"pod", entry.PodName},
+			{"namespace", entry.Namespace},
+			{"node", entry.NodeName},
+			{"body", entry.Body},
+		}
+	} else {
+		data = [][2]string{
+			{"level", entry.LogLevel},
+			{"uuid", entry.UUID},
+			{"trace", entry.TraceID},
+			{"span", entry.SpanID},
+			{"ip", entry.ServerIP},
+			{"pod", entry.PodName},
+			{"namespace", entry.Namespace},
+			{"node", entry.NodeName},
+			{"file", entry.File},
+			{"line", strconv.Itoa(entry.Line)},
+			{"func", entry.Function},
+		}
+	}
+
+	for _, f := range entry.Fields {
+		data = append(data, [2]string{syslogParamName(f.Key), f.ValueString()})
+	}
+
+	// RFC 3164 tidak punya STRUCTURED-DATA, jadi MSG berisi format text lengkap
+	message := entry.Message
+	if w.format == SyslogRFC3164 {
+		message = formatText(entry)
+	}
+	return w.write(levelFromString(entry.LogLevel).Severity(), entry.Time, msgID, data, message)
+}
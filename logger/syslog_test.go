@@ -0,0 +1,199 @@
+package logger
+
+import (
+	"bufio"
+	"io"
+	"net"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+func newTestSyslogSink(format SyslogFormat, network string) *SyslogSink {
+	return &SyslogSink{
+		network:  network,
+		format:   format,
+		facility: FacilityLocal0,
+		appName:  "payment",
+		hostname: "web-1",
+		pid:      42,
+	}
+}
+
+func TestSyslogBuild(t *testing.T) {
+	at := time.Date(2024, 3, 5, 7, 8, 9, 123456000, time.UTC)
+
+	tests := []struct {
+		name     string
+		format   SyslogFormat
+		severity SyslogSeverity
+		msgID    string
+		data     [][2]string
+		message  string
+		want     string
+	}{
+		{
+			name:     "rfc5424 with structured data",
+			format:   SyslogRFC5424,
+			severity: SeverityError,
+			msgID:    "STOP",
+			data:     [][2]string{{"level", "ERROR"}, {"txn", "abc"}, {"empty", ""}},
+			message:  "payment failed",
+			want:     `<131>1 2024-03-05T07:08:09.123456Z web-1 payment 42 STOP [logger@32473 level="ERROR" txn="abc"] payment failed`,
+		},
+		{
+			name:     "rfc5424 nil values",
+			format:   SyslogRFC5424,
+			severity: SeverityInfo,
+			data:     [][2]string{{"level", ""}},
+			message:  "hello",
+			want:     `<134>1 2024-03-05T07:08:09.123456Z web-1 payment 42 - - hello`,
+		},
+		{
+			name:     "rfc5424 escapes param values",
+			format:   SyslogRFC5424,
+			severity: SeverityWarning,
+			data:     [][2]string{{"body", `{"a":"b\c]"}`}},
+			message:  "x",
+			want:     `<132>1 2024-03-05T07:08:09.123456Z web-1 payment 42 - [logger@32473 body="{\"a\":\"b\\c\]\"}"] x`,
+		},
+		{
+			name:     "rfc3164",
+			format:   SyslogRFC3164,
+			severity: SeverityDebug,
+			message:  "[INFO] hello world",
+			want:     `<135>Mar  5 07:08:09 web-1 payment[42]: [INFO] hello world`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := newTestSyslogSink(tt.format, "udp")
+			if got := w.build(tt.severity, at, tt.msgID, tt.data, tt.message); got != tt.want {
+				t.Errorf("build =\n%s\nwant\n%s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSyslogHeaderFields(t *testing.T) {
+	w := newTestSyslogSink(SyslogRFC5424, "udp")
+	w.hostname = "web 1"
+	w.appName = strings.Repeat("a", 60)
+
+	got := w.build(SeverityInfo, time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), "MSG ID", nil, "m")
+	want := "<134>1 2024-01-01T00:00:00.000000Z web_1 " + strings.Repeat("a", 48) + " 42 MSG_ID - m"
+	if got != want {
+		t.Errorf("build =\n%s\nwant\n%s", got, want)
+	}
+	if name := syslogParamName(`a="b]` + strings.Repeat("c", 40)); name != "a__b_"+strings.Repeat("c", 27) {
+		t.Errorf("syslogParamName = %q", name)
+	}
+}
+
+func TestSyslogFrame(t *testing.T) {
+	tests := []struct {
+		network string
+		want    string
+	}{
+		{"tcp", "10 <134>1 msg"},
+		{"tcp6", "10 <134>1 msg"},
+		{"unix", "<134>1 msg\n"},
+		{"udp", "<134>1 msg"},
+		{"unixgram", "<134>1 msg"},
+	}
+	for _, tt := range tests {
+		w := newTestSyslogSink(SyslogRFC5424, tt.network)
+		if got := string(w.frame("<134>1 msg")); got != tt.want {
+			t.Errorf("frame(%s) = %q, want %q", tt.network, got, tt.want)
+		}
+	}
+}
+
+func TestNewSyslogSinkRejectsInvalidConfig(t *testing.T) {
+	tests := []struct {
+		name   string
+		config SyslogConfig
+	}{
+		{"unknown network", SyslogConfig{Network: "http", Address: "localhost:514"}},
+		{"missing address", SyslogConfig{Network: "tcp"}},
+		{"unknown format", SyslogConfig{Network: "udp", Address: "127.0.0.1:514", Format: "json"}},
+		{"facility too large", SyslogConfig{Network: "udp", Address: "127.0.0.1:514", Facility: FacilityLocal7 + 1}},
+		{"negative facility", SyslogConfig{Network: "udp", Address: "127.0.0.1:514", Facility: -1}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := NewSyslogSink(tt.config); err == nil {
+				t.Error("expected error")
+			}
+		})
+	}
+}
+
+func TestSyslogTCPRoundTrip(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer listener.Close()
+
+	received := make(chan []string, 1)
+	go func() {
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		r := bufio.NewReader(conn)
+
+		// Octet counting (RFC 6587): "<length> <message>"
+		var messages []string
+		for len(messages) < 2 {
+			length, err := r.ReadString(' ')
+			if err != nil {
+				break
+			}
+			n, _ := strconv.Atoi(strings.TrimSpace(length))
+			buf := make([]byte, n)
+			if _, err := io.ReadFull(r, buf); err != nil {
+				break
+			}
+			messages = append(messages, string(buf))
+		}
+		received <- messages
+	}()
+
+	sink, err := NewSyslogSink(SyslogConfig{
+		Network:  "tcp",
+		Address:  listener.Addr().String(),
+		AppName:  "payment",
+		Hostname: "web-1",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	at := time.Date(2024, 3, 5, 7, 8, 9, 0, time.UTC)
+	sink.Write(&LogEntry{Time: at, LogLevel: "WARNING", Message: "multi\nline", UUID: "u-1", Line: 10, File: "main.go",
+		Fields: []Field{String("order id", "ORD-1")}})
+	sink.Write(&LogEntry{Time: at, LogLevel: "ERROR", Flag: FlagStop, TransactionID: "txn-1", ServiceName: "svc", Message: "done"})
+	sink.Close()
+
+	select {
+	case messages := <-received:
+		if len(messages) != 2 {
+			t.Fatalf("got %d messages: %q", len(messages), messages)
+		}
+		pid := strconv.Itoa(sink.pid)
+		want := []string{
+			`<12>1 2024-03-05T07:08:09.000000Z web-1 payment ` + pid + ` - [logger@32473 level="WARNING" uuid="u-1" file="main.go" line="10" order_id="ORD-1"] multi` + "\nline",
+			`<11>1 2024-03-05T07:08:09.000000Z web-1 payment ` + pid + ` STOP [logger@32473 level="ERROR" txn="txn-1" service="svc"] done`,
+		}
+		for i := range want {
+			if messages[i] != want[i] {
+				t.Errorf("message %d =\n%s\nwant\n%s", i, messages[i], want[i])
+			}
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("no message received")
+	}
+}
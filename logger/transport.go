@@ -0,0 +1,178 @@
+package logger
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+)
+
+// TransportConfig untuk konfigurasi outbound HTTP client transport
+type TransportConfig struct {
+	ServiceName         string // Override nama service (default: dari context)
+	CaptureRequestBody  bool   // Tulis request body di entry START
+	CaptureResponseBody bool   // Tulis response body di entry STOP
+	MaxBodyBytes        int    // Batas body yang ditulis (default: 4096), sisanya diberi tanda "...(truncated)"
+	SkipHeaders         bool   // Jangan inject traceparent / X-Request-ID ke request
+
+	// Content type yang body-nya tidak ditulis (nil = DefaultSkipBodyContentTypes), misalnya
+	// text/event-stream sehingga STOP response streaming langsung ditulis tanpa menunggu body
+	SkipBodyContentTypes []string
+}
+
+// bodyConfig returns the body capture settings as a MiddlewareConfig
+func (c TransportConfig) bodyConfig() MiddlewareConfig {
+	return MiddlewareConfig{
+		CaptureRequestBody:   c.CaptureRequestBody,
+		MaxBodyBytes:         c.MaxBodyBytes,
+		SkipBodyContentTypes: c.SkipBodyContentTypes,
+	}
+}
+
+// loggingTransport is the http.RoundTripper returned by Transport
+type loggingTransport struct {
+	logger *Logger
+	base   http.RoundTripper
+	config TransportConfig
+}
+
+// Transport wraps base (nil berarti http.DefaultTransport) so every outgoing request
+// meneruskan trace dan transaction ID dari context dan menulis entry START/STOP.
+//
+//	client := &http.Client{Transport: appLogger.Transport(nil, logger.TransportConfig{})}
+//	req, _ := http.NewRequestWithContext(r.Context(), "GET", url, nil)
+func (l *Logger) Transport(base http.RoundTripper, config TransportConfig) http.RoundTripper {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	if config.MaxBodyBytes <= 0 {
+		config.MaxBodyBytes = defaultMaxBodyBytes
+	}
+	return &loggingTransport{logger: l, base: base, config: config}
+}
+
+// RoundTrip injects correlation headers, logs START, sends the request and logs STOP.
+// Dengan CaptureResponseBody, STOP ditulis saat response body selesai dibaca (EOF) atau di-close,
+// sehingga RoundTrip tidak pernah menunggu body dan durasi STOP mencakup waktu membaca body.
+func (t *loggingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx := t.outboundContext(req)
+
+	// RoundTripper tidak boleh mengubah request asli
+	req = req.Clone(req.Context())
+	if !t.config.SkipHeaders {
+		injectCorrelationHeaders(req.Header, CorrelationFromContext(ctx))
+	}
+
+	bodyConfig := t.config.bodyConfig()
+	reqBody := bodyConfig.ReadRequestBody(req)
+	t.logger.LogStart(ctx, "INFO", "Outbound request started", reqBody)
+
+	resp, err := t.base.RoundTrip(req)
+	if err != nil {
+		t.logger.LogStop(ctx, "ERROR", fmt.Sprintf("Outbound request failed: %v", err), "")
+		return resp, err
+	}
+
+	stopCtx := WithFields(ctx, Int("status", resp.StatusCode))
+	level := LevelForStatus(resp.StatusCode)
+	stop := func(body string) {
+		t.logger.LogStop(stopCtx, level, "Outbound request completed", body)
+	}
+
+	if t.config.CaptureResponseBody && resp.Body != nil && resp.Body != http.NoBody &&
+		bodyConfig.captureBodyFor(req.URL.Path, resp.Header.Get("Content-Type")) {
+		resp.Body = &loggedBody{
+			ReadCloser: resp.Body,
+			header:     resp.Header,
+			capture:    bodyConfig.NewResponseCapture(req.URL.Path),
+			stop:       stop,
+		}
+		return resp, nil
+	}
+
+	stop("")
+	return resp, nil
+}
+
+// loggedBody is a response body that records the first MaxBodyBytes while the caller reads it
+// dan menulis STOP satu kali saat EOF atau Close
+type loggedBody struct {
+	io.ReadCloser
+	header  http.Header
+	capture *BodyCapture
+	stop    func(body string)
+
+	mu      sync.Mutex
+	stopped bool
+}
+
+func (b *loggedBody) Read(p []byte) (int, error) {
+	n, err := b.ReadCloser.Read(p)
+	b.mu.Lock()
+	if !b.stopped {
+		b.capture.Capture(b.header, p[:n])
+	}
+	b.mu.Unlock()
+	if err == io.EOF {
+		b.finish()
+	}
+	return n, err
+}
+
+func (b *loggedBody) Close() error {
+	err := b.ReadCloser.Close()
+	b.finish()
+	return err
+}
+
+// finish writes STOP with the captured body, hanya sekali
+func (b *loggedBody) finish() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.stopped {
+		return
+	}
+	b.stopped = true
+	b.stop(b.capture.String())
+}
+
+// outboundContext builds the logging context of an outgoing request: trace dan transaction ID
+// dari context request, dengan span baru sebagai child dari span saat ini
+func (t *loggingTransport) outboundContext(req *http.Request) context.Context {
+	ctx := req.Context()
+
+	parent := CorrelationFromContext(ctx)
+	corr := newCorrelation(t.logger.ids)
+	if isHex(parent.TraceID, 32) {
+		corr.TraceID = parent.TraceID
+		corr.ParentSpanID = parent.SpanID
+		corr.TraceFlags = parent.TraceFlags
+		corr.TraceState = parent.TraceState
+	} else if parent.TraceID != "" {
+		// Trace ID custom (misalnya dari StartConfig.TraceID) tetap ditulis di log,
+		// tapi traceparent tidak di-inject karena formatnya bukan W3C
+		corr.TraceID = parent.TraceID
+	}
+	corr.TransactionID = getValueFromContext(ctx, TransactionIDKey, getValueFromContext(ctx, UUIDKey, corr.TraceID))
+	ctx = WithCorrelation(ctx, corr)
+
+	if t.config.ServiceName != "" {
+		ctx = WithServiceName(ctx, t.config.ServiceName)
+	}
+	ctx = WithMethod(ctx, req.Method)
+	ctx = WithEndpoint(ctx, req.URL.Redacted())
+	ctx = WithStartTime(ctx, t.logger.now())
+	return ctx
+}
+
+// injectCorrelationHeaders sets correlation headers that are not already present in h
+func injectCorrelationHeaders(h http.Header, c Correlation) {
+	out := http.Header{}
+	SetCorrelationHeaders(out, c)
+	for key, values := range out {
+		if h.Get(key) == "" {
+			h[key] = values
+		}
+	}
+}
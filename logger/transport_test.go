@@ -0,0 +1,105 @@
+package logger_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/funxdofficial/golang-module-syslog/logger"
+	"github.com/funxdofficial/golang-module-syslog/logger/logtest"
+)
+
+func TestTransportCapturesResponseBodyOnClose(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		io.WriteString(w, `{"invoice":"INV-1"}`)
+	}))
+	defer server.Close()
+
+	appLogger, logs := logtest.New(t)
+	client := &http.Client{Transport: appLogger.Transport(nil, logger.TransportConfig{CaptureResponseBody: true})}
+
+	resp, err := client.Get(server.URL + "/invoices")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n := len(logs.All().Flag(logger.FlagStop)); n != 0 {
+		t.Fatalf("STOP written before the body was read (%d entries)", n)
+	}
+
+	body, _ := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if string(body) != `{"invoice":"INV-1"}` {
+		t.Fatalf("body = %q", body)
+	}
+
+	stops := logs.All().Flag(logger.FlagStop)
+	if len(stops) != 1 {
+		t.Fatalf("got %d STOP entries, want 1", len(stops))
+	}
+	if stops[0].Body != `{"invoice":"INV-1"}` {
+		t.Errorf("STOP body = %q", stops[0].Body)
+	}
+	if len(stops.Field("status", 200)) != 1 {
+		t.Errorf("STOP has no status=200 field: %v", stops[0].Fields)
+	}
+}
+
+func TestTransportDoesNotWaitForEventStream(t *testing.T) {
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/event-stream")
+		io.WriteString(w, "data: ping\n\n")
+		w.(http.Flusher).Flush()
+		<-release
+	}))
+	defer server.Close()
+	defer close(release)
+
+	appLogger, logs := logtest.New(t)
+	client := &http.Client{Transport: appLogger.Transport(nil, logger.TransportConfig{CaptureResponseBody: true})}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	req, _ := http.NewRequestWithContext(ctx, "GET", server.URL+"/events", nil)
+	resp, err := client.Do(req)
+	if err != nil {
+		t.Fatalf("RoundTrip blocked on the event stream: %v", err)
+	}
+	defer resp.Body.Close()
+
+	stop := logs.AssertLogged(t, logger.LevelSuccess, "Outbound request completed")
+	if stop.Body != "" {
+		t.Errorf("event stream body captured: %q", stop.Body)
+	}
+}
+
+func TestTransportTruncatesResponseBody(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/plain")
+		io.WriteString(w, strings.Repeat("a", 100))
+	}))
+	defer server.Close()
+
+	appLogger, logs := logtest.New(t)
+	client := &http.Client{Transport: appLogger.Transport(nil, logger.TransportConfig{CaptureResponseBody: true, MaxBodyBytes: 10})}
+
+	resp, err := client.Get(server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	body, _ := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if len(body) != 100 {
+		t.Fatalf("caller read %d bytes, want 100", len(body))
+	}
+
+	stops := logs.All().Flag(logger.FlagStop)
+	if len(stops) != 1 || stops[0].Body != strings.Repeat("a", 10)+"...(truncated)" {
+		t.Fatalf("STOP entries = %v", stops.Messages())
+	}
+}